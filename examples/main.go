@@ -5,12 +5,9 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math/rand"
 	"net/http"
 	"strconv"
-	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 
@@ -69,49 +66,8 @@ func getProductDetailsFromAPI(id int) (string, error) {
 	return string(body), nil
 }
 
-// getProduct retrieves a product from the cache or API, updating global stats.
-func getProduct(id int, cache *lrucache.LRUCache) (string, error) {
-	key := fmt.Sprintf("product_%d", id)
-
-	if value, ok := cache.Get(key); ok {
-		stats.RecordHit()
-		return value, nil
-	}
-
-	stats.RecordMiss()
-
-	product, err := getProductDetailsFromAPI(id)
-	if err != nil {
-		return "", err
-	}
-
-	cache.Put(key, product)
-	return product, nil
-}
-
-// benchmarkCacheHit simulates concurrent users requesting products and returns benchmark stats.
-func benchmarkCacheHit(cache *lrucache.LRUCache, users, productRange int) (int64, int64, float64) {
-	localStats := &CacheStats{} // Local stats for this benchmark run
-	var wg sync.WaitGroup
-
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	for range users {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			id := r.Intn(productRange) + 1
-			_, _ = getProductWithStats(id, cache, localStats)
-		}()
-	}
-
-	wg.Wait()
-
-	return localStats.GetStats()
-}
-
-// getProductWithStats is used by the benchmark to track hits/misses in local stats.
-func getProductWithStats(id int, cache *lrucache.LRUCache, stats *CacheStats) (string, error) {
+// getProduct retrieves a product from the request's cache scope or the API, updating global stats.
+func getProduct(id int, cache *lrucache.Scope) (string, error) {
 	key := fmt.Sprintf("product_%d", id)
 
 	if value, ok := cache.Get(key); ok {
@@ -141,6 +97,7 @@ func main() {
 	}
 
 	app := fiber.New(config)
+	app.Use(lrucache.FiberScopeMiddleware(cache))
 
 	// Hello World endpoint
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -163,12 +120,24 @@ func main() {
 			})
 		}
 
-		product, err := getProduct(id, cache)
+		// Answer conditional requests from the cache without rebuilding the response
+		key := fmt.Sprintf("product_%d", id)
+		if match := c.Get(fiber.HeaderIfNoneMatch); match != "" {
+			if _, notModified, _ := cache.GetIfNoneMatch(key, match); notModified {
+				return c.SendStatus(fiber.StatusNotModified)
+			}
+		}
+
+		scope, _ := lrucache.ScopeFromContext(c.UserContext())
+		product, err := getProduct(id, scope)
 		if err != nil {
 			return c.Status(500).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
+		if etag, ok := cache.ETag(key); ok {
+			c.Set(fiber.HeaderETag, etag)
+		}
 
 		var productDetails map[string]interface{}
 		err = json.Unmarshal([]byte(product), &productDetails)
@@ -183,7 +152,7 @@ func main() {
 		})
 	})
 
-	// Benchmark endpoint: http://localhost:8080/stats?users=20&range=3
+	// Benchmark endpoint: http://localhost:8080/stats?users=20&range=3&ops=10000&dist=zipf
 	app.Get("/stats", func(c *fiber.Ctx) error {
 		users, err := strconv.Atoi(c.Query("users", "20"))
 		if err != nil {
@@ -193,13 +162,36 @@ func main() {
 		if err != nil {
 			return c.Status(400).JSON(fiber.Map{"error": "Invalid range parameter"})
 		}
+		ops, err := strconv.Atoi(c.Query("ops", "10000"))
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid ops parameter"})
+		}
+
+		distribution := lrucache.Uniform
+		if c.Query("dist") == "zipf" {
+			distribution = lrucache.Zipfian
+		}
 
-		hits, misses, hitRate := benchmarkCacheHit(cache, users, productRange)
+		// Run against a scratch cache of the same capacity so the live cache is left untouched
+		benchCache, err := lrucache.NewLRUCache(cache.Capacity)
+		if err != nil {
+			return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		result := lrucache.Benchmark(benchCache, lrucache.BenchConfig{
+			Goroutines:   users,
+			Operations:   ops,
+			Keys:         productRange,
+			Distribution: distribution,
+		})
 		return c.JSON(fiber.Map{
-			"hits":     hits,
-			"misses":   misses,
-			"hit_rate": fmt.Sprintf("%.2f", hitRate),
-			"total":    hits + misses,
+			"hits":       result.Hits,
+			"misses":     result.Misses,
+			"hit_rate":   fmt.Sprintf("%.2f", result.HitRate),
+			"total":      result.Hits + result.Misses,
+			"throughput": fmt.Sprintf("%.0f", result.Throughput),
+			"p50":        result.P50.String(),
+			"p99":        result.P99.String(),
 		})
 	})
 
@@ -0,0 +1,226 @@
+package lrucache
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxAdminBody bounds the request bodies accepted by the admin handler.
+const maxAdminBody = 1 << 20
+
+// Handler serves an HTTP admin API for an LRUCache:
+//
+//	GET    /keys            keys, most recently used first
+//	GET    /entries         entries, most recently used first
+//	GET    /entries/{key}   a single value, without promoting it
+//	PUT    /entries/{key}   store the request body as the value
+//	DELETE /entries/{key}   delete a key
+//	POST   /clear           remove every entry
+//	GET    /stats           cache statistics, including the memory estimate
+//	GET    /debug/events    the event log, filtered with ?key=
+//	GET    /export          all entries as an ExportStream
+//	POST   /import          load an ExportStream from the request body
+//	GET    /healthz         200 if Healthcheck passes, 503 with its error otherwise
+//
+// The routes that return values (GET /entries, /entries/{key} and /export)
+// answer 404 Not Found unless enabled with WithValueRoutes; /keys never
+// includes values. Mount it under a prefix with http.StripPrefix, and
+// protect it with WithAuth before exposing it beyond localhost.
+type Handler struct {
+	cache       *LRUCache
+	mux         *http.ServeMux
+	auth        func(r *http.Request) bool
+	readOnly    bool
+	valueRoutes bool
+}
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithAuth only serves requests for which check returns true. Others are
+// rejected with 401 Unauthorized if they carry no Authorization header and
+// 403 Forbidden otherwise.
+func WithAuth(check func(r *http.Request) bool) HandlerOption {
+	return func(h *Handler) {
+		h.auth = check
+	}
+}
+
+// WithReadOnly disables the routes that modify the cache (PUT and DELETE
+// /entries/{key}, /clear and /import), which then answer 405 Method Not
+// Allowed. The GET routes keep working.
+func WithReadOnly(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.readOnly = enabled
+	}
+}
+
+// WithValueRoutes enables the routes that return cached values: GET
+// /entries, /entries/{key} and /export. They are off by default, including
+// in read-only mode, since values may hold data the keys and stats do not.
+func WithValueRoutes(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.valueRoutes = enabled
+	}
+}
+
+// NewHandler creates an admin Handler for cache.
+func NewHandler(cache *LRUCache, opts ...HandlerOption) *Handler {
+	h := &Handler{cache: cache, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /keys", h.keys)
+	h.mux.HandleFunc("GET /entries", h.values(h.entries))
+	h.mux.HandleFunc("GET /entries/{key}", h.values(h.getEntry))
+	h.mux.HandleFunc("PUT /entries/{key}", h.write(h.putEntry))
+	h.mux.HandleFunc("DELETE /entries/{key}", h.write(h.deleteEntry))
+	h.mux.HandleFunc("POST /clear", h.write(h.clear))
+	h.mux.HandleFunc("GET /stats", h.stats)
+	h.mux.HandleFunc("GET /debug/events", h.events)
+	h.mux.HandleFunc("GET /export", h.values(h.exportStream))
+	h.mux.HandleFunc("POST /import", h.write(h.importStream))
+	h.mux.HandleFunc("GET /healthz", h.healthz)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth != nil && !h.auth(r) {
+		if r.Header.Get("Authorization") == "" {
+			writeError(w, http.StatusUnauthorized, "authentication required")
+		} else {
+			writeError(w, http.StatusForbidden, "access denied")
+		}
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// write guards a route that modifies the cache against read-only mode.
+func (h *Handler) write(fn http.HandlerFunc) http.HandlerFunc {
+	if !h.readOnly {
+		return fn
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "admin handler is read-only")
+	}
+}
+
+// values guards a route that returns cached values unless WithValueRoutes
+// enabled them.
+func (h *Handler) values(fn http.HandlerFunc) http.HandlerFunc {
+	if h.valueRoutes {
+		return fn
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "value routes disabled, see WithValueRoutes")
+	}
+}
+
+func (h *Handler) keys(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.cache.Keys())
+}
+
+func (h *Handler) entries(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.cache.Entries())
+}
+
+func (h *Handler) getEntry(w http.ResponseWriter, r *http.Request) {
+	key := h.cache.normalizeKey(r.PathValue("key"))
+
+	h.cache.mutex.RLock()
+	node, ok := h.cache.Cache[key]
+	found := ok && h.cache.live(node)
+	var entry Entry
+	if found {
+		entry = node.entry()
+	}
+	h.cache.mutex.RUnlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, entry)
+}
+
+func (h *Handler) putEntry(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAdminBody))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err := h.cache.PutE(r.PathValue("key"), string(body)); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) deleteEntry(w http.ResponseWriter, r *http.Request) {
+	if !h.cache.Delete(r.PathValue("key")) {
+		writeError(w, http.StatusNotFound, "key not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) clear(w http.ResponseWriter, r *http.Request) {
+	h.cache.Clear()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) stats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, h.cache.Stats())
+}
+
+func (h *Handler) events(w http.ResponseWriter, r *http.Request) {
+	if h.cache.events == nil {
+		writeError(w, http.StatusNotFound, "event log disabled, see WithEventLog")
+		return
+	}
+
+	if key := r.URL.Query().Get("key"); key != "" {
+		writeJSON(w, http.StatusOK, h.cache.EventLogForKey(key))
+		return
+	}
+	writeJSON(w, http.StatusOK, h.cache.EventLog())
+}
+
+func (h *Handler) healthz(w http.ResponseWriter, r *http.Request) {
+	if err := h.cache.Healthcheck(); err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func (h *Handler) exportStream(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	// Headers are already sent once streaming starts, so errors can only cut it short
+	_ = h.cache.ExportStream(w)
+}
+
+func (h *Handler) importStream(w http.ResponseWriter, r *http.Request) {
+	if err := h.cache.ImportStream(r.Body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package lrucache
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serve sends a request with an optional body to h and returns the response.
+func serve(h http.Handler, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestAdminAuth(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	h := NewHandler(c, WithAuth(func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer secret"
+	}))
+
+	for _, tc := range []struct {
+		auth string
+		want int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusForbidden},
+		{"Bearer secret", http.StatusOK},
+	} {
+		header := http.Header{}
+		if tc.auth != "" {
+			header.Set("Authorization", tc.auth)
+		}
+		if w := serve(h, "GET", "/stats", "", header); w.Code != tc.want {
+			t.Fatalf("GET /stats with Authorization %q = %d, want %d", tc.auth, w.Code, tc.want)
+		}
+	}
+
+	// Rejected requests must not reach the cache.
+	serve(h, "PUT", "/entries/k", "v", nil)
+	if c.Size() != 0 {
+		t.Fatal("an unauthenticated PUT stored an entry")
+	}
+}
+
+func TestAdminReadOnly(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("k", "v")
+	h := NewHandler(c, WithReadOnly(true))
+
+	for _, route := range []struct{ method, target, body string }{
+		{"PUT", "/entries/x", "v"},
+		{"DELETE", "/entries/k", ""},
+		{"POST", "/clear", ""},
+		{"POST", "/import", ""},
+	} {
+		w := serve(h, route.method, route.target, route.body, nil)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("%s %s = %d, want 405", route.method, route.target, w.Code)
+		}
+		if w.Header().Get("Allow") != "GET, HEAD" {
+			t.Fatalf("%s %s Allow = %q", route.method, route.target, w.Header().Get("Allow"))
+		}
+	}
+	if !c.Has("k") || c.Has("x") {
+		t.Fatalf("keys = %v, read-only handler modified the cache", listKeys(c))
+	}
+	for _, target := range []string{"/keys", "/stats", "/healthz"} {
+		if w := serve(h, "GET", target, "", nil); w.Code != http.StatusOK {
+			t.Fatalf("GET %s = %d, want 200", target, w.Code)
+		}
+	}
+}
+
+func TestAdminValueRoutes(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("k", "secret-value")
+
+	for _, opts := range [][]HandlerOption{nil, {WithReadOnly(true)}} {
+		h := NewHandler(c, opts...)
+		for _, target := range []string{"/entries", "/entries/k", "/export"} {
+			w := serve(h, "GET", target, "", nil)
+			if w.Code != http.StatusNotFound || strings.Contains(w.Body.String(), "secret-value") {
+				t.Fatalf("GET %s without WithValueRoutes = %d %q, want 404 without the value", target, w.Code, w.Body)
+			}
+		}
+		if w := serve(h, "GET", "/keys", "", nil); strings.Contains(w.Body.String(), "secret-value") {
+			t.Fatalf("GET /keys exposed a value: %q", w.Body)
+		}
+	}
+
+	h := NewHandler(c, WithReadOnly(true), WithValueRoutes(true))
+	for _, target := range []string{"/entries", "/entries/k", "/export"} {
+		w := serve(h, "GET", target, "", nil)
+		if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte("secret-value")) {
+			t.Fatalf("GET %s with WithValueRoutes = %d %q, want the value", target, w.Code, w.Body)
+		}
+	}
+	if w := serve(h, "GET", "/entries/missing", "", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("GET /entries/missing = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,40 @@
+package lrucache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetWithAge(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(2, WithClock(func() time.Time { return now }))
+	c.Put("a", "1")
+
+	now = now.Add(90 * time.Second)
+	value, age, ok := c.GetWithAge("a")
+	if !ok || value != "1" || age != 90*time.Second {
+		t.Fatalf("GetWithAge(a) = %q, %v, %v, want 1, 1m30s, true", value, age, ok)
+	}
+
+	// Reads do not reset the age, updates do.
+	now = now.Add(time.Minute)
+	if _, age, _ := c.GetWithAge("a"); age != 150*time.Second {
+		t.Fatalf("age after a read = %v, want 2m30s", age)
+	}
+	c.Put("a", "2")
+	now = now.Add(time.Second)
+	if value, age, _ := c.GetWithAge("a"); value != "2" || age != time.Second {
+		t.Fatalf("GetWithAge(a) after an update = %q, %v, want 2, 1s", value, age)
+	}
+
+	// GetWithAge promotes like Get.
+	c.Put("b", "1")
+	c.GetWithAge("a")
+	c.Put("c", "1")
+	if !c.Has("a") || c.Has("b") {
+		t.Fatal("GetWithAge did not mark a as recently used")
+	}
+	if _, _, ok := c.GetWithAge("b"); ok {
+		t.Fatal("GetWithAge reported an evicted key")
+	}
+}
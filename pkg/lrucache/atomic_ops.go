@@ -0,0 +1,137 @@
+package lrucache
+
+// GetAndDelete removes key and returns the value it held, in one step.
+func (c *LRUCache) GetAndDelete(key string) (string, bool) {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || !c.live(node) {
+		c.recordEvent("delete", key, "absent")
+		return "", false
+	}
+	value := node.Value
+	c.removeEntry(node, ReasonDeleted)
+	c.recordEvent("delete", key, "deleted")
+	return value, true
+}
+
+// CompareAndDelete removes key only if it still holds value, so a caller that
+// found a bad value can drop it without racing a concurrent Put of a good one.
+// Returns true if the entry was removed.
+func (c *LRUCache) CompareAndDelete(key, value string) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || node.Value != value {
+		return false
+	}
+	c.removeEntry(node, ReasonDeleted)
+	c.recordEvent("delete", key, "deleted")
+	return true
+}
+
+// Swap stores value under key and returns the value it replaces, in one step.
+// Like Put it inserts a missing key, promotes it and evicts to stay within
+// the capacity; the WithOnFull policy does not apply. Values rejected by the
+// byte limit leave the key untouched.
+func (c *LRUCache) Swap(key, value string) (old string, existed bool) {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	if node, ok := c.Cache[key]; ok && c.live(node) {
+		old, existed = node.Value, true
+	}
+	if err := c.put(key, value, nil, c.ttl); err != nil {
+		c.recordEvent("put", key, "rejected")
+		return old, existed
+	}
+	c.recordEvent("put", key, "stored")
+	return old, existed
+}
+
+// Rename moves the entry under oldKey to newKey in one step, keeping its
+// value, metadata, expiry and position in the recency order. An entry already
+// under newKey is replaced and reported to the eviction callbacks as
+// ReasonDeleted. Like a Put, newKey must pass WithMaxKeyBytes and the key
+// validator, and a longer key evicts other entries to stay within the byte
+// limit. Returns false, leaving the entry under oldKey, if oldKey is absent
+// or newKey is rejected.
+func (c *LRUCache) Rename(oldKey, newKey string) bool {
+	oldKey, newKey = c.normalizeKey(oldKey), c.normalizeKey(newKey)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[oldKey]
+	if !ok || !c.live(node) {
+		return false
+	}
+	if oldKey == newKey {
+		return true
+	}
+	// The key length is part of the entry cost
+	delta := int64(len(newKey) - len(oldKey))
+	if err := c.checkEntry(newKey, node.cost+delta); err != nil {
+		return false
+	}
+
+	if existing, ok := c.Cache[newKey]; ok {
+		c.removeEntry(existing, ReasonDeleted)
+	}
+	if delta > 0 {
+		// Make room for the longer key without evicting the entry itself
+		pinned := node.pinned
+		node.pinned = true
+		c.evictOverflow(0, delta)
+		node.pinned = pinned
+		if c.overflows(0, delta) {
+			return false
+		}
+	}
+	delete(c.Cache, oldKey)
+	node.Key = newKey
+	c.Cache[newKey] = node
+	if c.index != nil {
+		c.index.remove(c.index.hash(oldKey), node)
+		c.index.insert(c.index.hash(newKey), node)
+	}
+	if c.reserve != nil {
+		c.reserve.add(oldKey, -1)
+		c.reserve.add(newKey, 1)
+	}
+	node.cost += delta
+	c.bytes += delta
+
+	c.auditOp("delete", oldKey, node.Value)
+	c.auditOp("put", newKey, node.Value)
+	c.replicateDelete(oldKey)
+	c.replicatePut(node)
+	c.signalStored(newKey)
+	c.recordEvent("delete", oldKey, "renamed")
+	c.recordEvent("put", newKey, "renamed")
+	return true
+}
+
+// Upsert stores value under key, or merge(existing, value) if the key is
+// present, as a single read-modify-write under the write lock. merge runs
+// with the lock held, so it must be quick and must not call back into the
+// cache. Like Put it promotes the key, evicts to make room and silently drops
+// entries rejected by the byte limit; the WithOnFull policy does not apply.
+func (c *LRUCache) Upsert(key string, value string, merge func(existing, new string) string) {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	if node, ok := c.Cache[key]; ok && c.live(node) {
+		value = merge(node.Value, value)
+	}
+	if err := c.put(key, value, nil, c.ttl); err != nil {
+		c.recordEvent("put", key, "rejected")
+		return
+	}
+	c.recordEvent("put", key, "stored")
+}
@@ -0,0 +1,122 @@
+package lrucache
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(4, WithOnEvict(r.onEvict))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+
+	if !c.Rename("b", "z") {
+		t.Fatal("Rename(b, z) = false")
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"c", "z", "a"}) {
+		t.Fatalf("keys = %v, want z in b's position", got)
+	}
+	if !c.Rename("z", "a") {
+		t.Fatal("Rename onto an existing key = false")
+	}
+	if value, _ := c.Get("a"); value != "2" || c.Size() != 2 {
+		t.Fatalf("Get(a) = %q with size %d, want the renamed value 2 and size 2", value, c.Size())
+	}
+	if want := []eviction{{key: "a", value: "1", reason: ReasonDeleted}}; !slices.Equal(r.got(), want) {
+		t.Fatalf("callbacks = %+v, want %+v", r.got(), want)
+	}
+	if c.Rename("missing", "x") || c.Has("x") {
+		t.Fatal("Rename of a missing key succeeded")
+	}
+	checkIntegrity(t, c)
+}
+
+func TestRenameValidatesNewKey(t *testing.T) {
+	errBad := errors.New("bad key")
+	c, _ := NewLRUCacheWithOptions(4,
+		WithMaxKeyBytes(4),
+		WithKeyValidator(func(key string) error {
+			if strings.HasPrefix(key, "bad") {
+				return errBad
+			}
+			return nil
+		}),
+	)
+	c.Put("a", "1")
+
+	for _, newKey := range []string{"toolong", "bad"} {
+		if c.Rename("a", newKey) {
+			t.Fatalf("Rename to %q succeeded", newKey)
+		}
+		if value, ok := c.Get("a"); !ok || value != "1" || c.Has(newKey) {
+			t.Fatalf("rejected Rename to %q moved the entry", newKey)
+		}
+	}
+	if got := c.Stats().Rejected; got != 1 {
+		t.Fatalf("Stats().Rejected = %d, want 1", got)
+	}
+}
+
+func TestRenameRespectsMaxBytes(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithMaxBytes(20))
+	c.Put("a", "12345")
+	c.Put("b", "12345")
+	c.Put("c", "12345") // 18 bytes, a is the tail
+
+	if !c.Rename("a", "aaaa") {
+		t.Fatal("Rename to a longer key = false")
+	}
+	if c.bytes > 20 {
+		t.Fatalf("bytes = %d after Rename, above the limit of 20", c.bytes)
+	}
+	if !c.Has("aaaa") || c.Has("b") || !c.Has("c") {
+		t.Fatalf("keys = %v, want b evicted and the renamed tail entry kept", listKeys(c))
+	}
+
+	if c.Rename("c", strings.Repeat("c", 20)) {
+		t.Fatal("Rename to a key that cannot fit the byte limit succeeded")
+	}
+	if !c.Has("c") {
+		t.Fatal("rejected Rename dropped the entry")
+	}
+	checkIntegrity(t, c)
+}
+
+func TestGetAndDeleteRace(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	for round := range 50 {
+		c.Put("token", "once")
+
+		start := make(chan struct{})
+		var winners atomic.Int32
+		var wg sync.WaitGroup
+		for range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				if value, ok := c.GetAndDelete("token"); ok {
+					if value != "once" {
+						t.Errorf("GetAndDelete = %q, want once", value)
+					}
+					winners.Add(1)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		if n := winners.Load(); n != 1 {
+			t.Fatalf("round %d: %d goroutines got the token, want 1", round, n)
+		}
+		if c.Has("token") {
+			t.Fatalf("round %d: token still cached", round)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package lrucache
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// auditQueueSize is how many audit records can wait for the flusher.
+const auditQueueSize = 1024
+
+// auditRecord is one JSON line of the audit log.
+type auditRecord struct {
+	Op        string    `json:"op"`
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	Time      time.Time `json:"ts"`
+	Goroutine uint64    `json:"goroutine"`
+
+	flushed chan error // set on flush markers, which are not written
+}
+
+// auditLog writes audit records to an io.Writer from a background goroutine.
+type auditLog struct {
+	records chan auditRecord
+	done    chan struct{}
+
+	mu  sync.Mutex
+	err error // first write error since the last flush
+}
+
+// WithAuditLog writes one JSON line per mutation to w, as an append-only audit
+// trail: {"op":"put|delete|evict|clear","key":...,"value":...,"ts":...,"goroutine":N}.
+// Records are queued to a buffered channel and written by a background goroutine,
+// so a slow writer never stalls cache operations: once the queue is full, new
+// records are dropped and counted in Stats.AuditDropped. Values are left empty in secure erase mode. Call FlushAuditLog to wait for the
+// queued records and Close to stop the writer goroutine.
+func WithAuditLog(w io.Writer) Option {
+	return func(c *LRUCache) {
+		a := &auditLog{
+			records: make(chan auditRecord, auditQueueSize),
+			done:    make(chan struct{}),
+		}
+		go a.run(w)
+		c.audit = a
+	}
+}
+
+// run writes queued records until the channel is closed.
+func (a *auditLog) run(w io.Writer) {
+	defer close(a.done)
+
+	enc := json.NewEncoder(w)
+	for record := range a.records {
+		if record.flushed != nil {
+			record.flushed <- a.flush(w)
+			continue
+		}
+		if err := enc.Encode(record); err != nil {
+			a.mu.Lock()
+			if a.err == nil {
+				a.err = err
+			}
+			a.mu.Unlock()
+		}
+	}
+	a.flush(w)
+}
+
+// flush flushes w if it buffers, and returns and resets the first write error.
+func (a *auditLog) flush(w io.Writer) error {
+	var err error
+	if f, ok := w.(interface{ Flush() error }); ok {
+		err = f.Flush()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err != nil {
+		err, a.err = a.err, nil
+	}
+	return err
+}
+
+// FlushAuditLog blocks until every audit record queued so far has been written
+// and returns the first write error since the previous flush, if any.
+// Writers with a Flush() error method, such as *bufio.Writer, are flushed too.
+func (c *LRUCache) FlushAuditLog() error {
+	c.mutex.RLock()
+	a := c.audit
+	if a == nil {
+		c.mutex.RUnlock()
+		return nil
+	}
+	flushed := make(chan error, 1)
+	a.records <- auditRecord{flushed: flushed}
+	c.mutex.RUnlock()
+
+	return <-flushed
+}
+
+// Close stops the background goroutines started by the options, writing out
+// any queued audit records and replica operations first. The cache stays
+// usable afterwards, without those features.
+func (c *LRUCache) Close() error {
+	c.stopReaper()
+	replicaErr := c.stopReplica()
+
+	c.mutex.Lock()
+	a := c.audit
+	c.audit = nil
+	c.mutex.Unlock()
+
+	if a == nil {
+		return replicaErr
+	}
+	close(a.records)
+	<-a.done
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err != nil {
+		return a.err
+	}
+	return replicaErr
+}
+
+// auditOp queues an audit record, or drops it if the queue is full so the
+// write lock is never held waiting for the writer. The caller must hold the
+// write lock, which also keeps the records in mutation order.
+func (c *LRUCache) auditOp(op, key, value string) {
+	if c.audit == nil {
+		return
+	}
+	if len(c.audit.records) == cap(c.audit.records) {
+		// Skip the goroutine id parse for a record that cannot be queued
+		c.auditDropped++
+		return
+	}
+	if c.secureErase {
+		value = ""
+	}
+	record := auditRecord{
+		Op:        op,
+		Key:       key,
+		Value:     value,
+		Time:      c.now(),
+		Goroutine: goroutineID(),
+	}
+	select {
+	case c.audit.records <- record:
+	default:
+		c.auditDropped++
+	}
+}
+
+// goroutineID parses the current goroutine's id from its stack header,
+// "goroutine N [running]:". Go offers no API for it; it is only used to
+// correlate audit records.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
@@ -0,0 +1,93 @@
+package lrucache
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter holds every Write until release is closed.
+type blockingWriter struct {
+	release chan struct{}
+	mutex   sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.Write(p)
+}
+
+func TestAuditLogRecords(t *testing.T) {
+	var buf bytes.Buffer
+	c, _ := NewLRUCacheWithOptions(1, WithAuditLog(&buf))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Delete("b")
+	c.Clear()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var ops []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record auditRecord
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Goroutine == 0 {
+			t.Fatalf("record %+v has no goroutine id", record)
+		}
+		ops = append(ops, record.Op+":"+record.Key)
+	}
+	want := []string{"put:a", "evict:a", "put:b", "delete:b", "clear:"}
+	if !slices.Equal(ops, want) {
+		t.Fatalf("audit ops = %v, want %v", ops, want)
+	}
+}
+
+func TestAuditLogDropsWhenFull(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	c, _ := NewLRUCacheWithOptions(10_000, WithAuditLog(w))
+
+	const puts = 3 * auditQueueSize
+	done := make(chan struct{})
+	go func() {
+		for i := range puts {
+			c.Put(strconv.Itoa(i), "v")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Put blocked on a stalled audit writer")
+	}
+
+	dropped := c.Stats().AuditDropped
+	if dropped < puts-auditQueueSize-1 {
+		t.Fatalf("AuditDropped = %d, want at least %d", dropped, puts-auditQueueSize-1)
+	}
+	if got := c.MetricsSnapshot()["audit_dropped"]; got != float64(dropped) {
+		t.Fatalf("audit_dropped metric = %v, want %d", got, dropped)
+	}
+
+	close(w.release)
+	if err := c.FlushAuditLog(); err != nil {
+		t.Fatal(err)
+	}
+	w.mutex.Lock()
+	lines := bytes.Count(w.buf.Bytes(), []byte("\n"))
+	w.mutex.Unlock()
+	if uint64(lines)+dropped != puts {
+		t.Fatalf("%d records written and %d dropped, want %d in total", lines, dropped, puts)
+	}
+	c.Close()
+}
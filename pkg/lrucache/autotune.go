@@ -0,0 +1,154 @@
+package lrucache
+
+import "time"
+
+// AutoTuneConfig bounds and paces the capacity auto-tuner.
+type AutoTuneConfig struct {
+	MinCapacity     int     // floor the tuner may shrink to, defaults to the initial capacity / 2
+	MaxCapacity     int     // ceiling the tuner may grow to, defaults to the initial capacity * 2
+	Step            int     // capacity change per adjustment, defaults to 10% of the initial capacity
+	Interval        int     // lookups between two decisions, defaults to 1000
+	GhostSize       int     // recently evicted keys remembered, defaults to MaxCapacity
+	GrowThreshold   float64 // ghost hits per lookup above which to grow, defaults to 0.05
+	ShrinkThreshold float64 // tail hits per lookup below which to shrink, defaults to 0.01
+}
+
+// autoTuner tracks the marginal utility of the cache capacity.
+type autoTuner struct {
+	cfg     AutoTuneConfig
+	enabled bool
+
+	ghost     map[string]struct{} // keys evicted recently
+	ghostRing []string
+	ghostNext int
+
+	lookups   int
+	ghostHits int // misses that would have hit with more capacity
+	tailHits  int // hits on entries sampled in the tail segment
+
+	ghostHitRate   float64
+	lastAdjustment time.Time
+}
+
+// WithAutoTune enables automatic capacity tuning. The cache remembers a ghost
+// list of recently evicted keys: when misses on ghost keys exceed
+// GrowThreshold per lookup, capacity grows by Step up to MaxCapacity; when the
+// tail tenth of the cache sees fewer than ShrinkThreshold hits per lookup, it
+// shrinks by Step down to MinCapacity. Decisions are taken every Interval
+// lookups and use Resize semantics.
+func WithAutoTune(cfg AutoTuneConfig) Option {
+	return func(c *LRUCache) {
+		if c.Capacity == NoLimit {
+			return
+		}
+		if cfg.MinCapacity <= 0 {
+			cfg.MinCapacity = max(c.Capacity/2, 1)
+		}
+		if cfg.MaxCapacity < cfg.MinCapacity {
+			cfg.MaxCapacity = max(c.Capacity*2, cfg.MinCapacity)
+		}
+		if cfg.Step <= 0 {
+			cfg.Step = max(c.Capacity/10, 1)
+		}
+		if cfg.Interval <= 0 {
+			cfg.Interval = 1000
+		}
+		if cfg.GhostSize <= 0 {
+			cfg.GhostSize = cfg.MaxCapacity
+		}
+		if cfg.GrowThreshold <= 0 {
+			cfg.GrowThreshold = 0.05
+		}
+		if cfg.ShrinkThreshold <= 0 {
+			cfg.ShrinkThreshold = 0.01
+		}
+
+		c.tuner = &autoTuner{
+			cfg:       cfg,
+			enabled:   true,
+			ghost:     make(map[string]struct{}, cfg.GhostSize),
+			ghostRing: make([]string, cfg.GhostSize),
+		}
+	}
+}
+
+// SetAutoTune pauses or resumes the auto-tuner at runtime.
+// It has no effect on caches built without WithAutoTune.
+func (c *LRUCache) SetAutoTune(enabled bool) {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	if c.tuner != nil {
+		c.tuner.enabled = enabled
+	}
+}
+
+// rememberGhost adds an evicted key to the ghost list, forgetting the oldest one.
+// The caller must hold the write lock.
+func (c *LRUCache) rememberGhost(key string) {
+	t := c.tuner
+	if t == nil || !t.enabled {
+		return
+	}
+
+	if old := t.ghostRing[t.ghostNext]; old != "" {
+		delete(t.ghost, old)
+	}
+	t.ghostRing[t.ghostNext] = key
+	t.ghostNext = (t.ghostNext + 1) % len(t.ghostRing)
+	t.ghost[key] = struct{}{}
+}
+
+// tuneHit accounts a hit. The caller must hold the write lock.
+func (c *LRUCache) tuneHit(node *Node) {
+	t := c.tuner
+	if t == nil || !t.enabled {
+		return
+	}
+
+	if node.tailMark {
+		node.tailMark = false
+		t.tailHits++
+	}
+	c.tuneLookup()
+}
+
+// tuneMiss accounts a miss. The caller must hold the write lock.
+func (c *LRUCache) tuneMiss(key string) {
+	t := c.tuner
+	if t == nil || !t.enabled {
+		return
+	}
+
+	if _, ok := t.ghost[key]; ok {
+		t.ghostHits++
+	}
+	c.tuneLookup()
+}
+
+// tuneLookup takes a sizing decision once enough lookups were seen.
+func (c *LRUCache) tuneLookup() {
+	t := c.tuner
+	t.lookups++
+	if t.lookups < t.cfg.Interval {
+		return
+	}
+
+	t.ghostHitRate = float64(t.ghostHits) / float64(t.lookups)
+	tailHitRate := float64(t.tailHits) / float64(t.lookups)
+	t.lookups, t.ghostHits, t.tailHits = 0, 0, 0
+
+	switch {
+	case t.ghostHitRate > t.cfg.GrowThreshold && c.Capacity < t.cfg.MaxCapacity:
+		c.resize(min(c.Capacity+t.cfg.Step, t.cfg.MaxCapacity))
+		t.lastAdjustment = c.now()
+	case t.ghostHitRate <= t.cfg.GrowThreshold && tailHitRate < t.cfg.ShrinkThreshold && c.Capacity > t.cfg.MinCapacity:
+		c.resize(max(c.Capacity-t.cfg.Step, t.cfg.MinCapacity))
+		t.lastAdjustment = c.now()
+	}
+
+	// Sample the tail tenth of the list for the next period
+	for node, i := c.Tail, 0; node != nil && i < max(c.Capacity/10, 1); node, i = node.Prev, i+1 {
+		node.tailMark = true
+	}
+}
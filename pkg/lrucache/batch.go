@@ -0,0 +1,87 @@
+package lrucache
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BatchError reports the entry that made PutAllOrNothing reject a batch.
+type BatchError struct {
+	Key string // the offending key, as passed in
+	Err error  // why it was rejected, e.g. ErrOversizedValue or a validator error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch rejected at key %q: %v", e.Key, e.Err)
+}
+
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// PutAllOrNothing stores every entry or none of them. Under a single write
+// lock it first checks each entry against the key and byte limits and the key
+// validator, then that the batch fits the capacity and byte limit as a whole,
+// so entries do not evict each other, and, under WithOnFull(ErrorPolicy) or
+// BlockPolicy, that it fits without evicting. Only if all checks pass are the
+// entries written, in key order.
+//
+// The guarantee is about validation, not rollback: writing the batch may
+// evict other entries, which stay evicted. A rejected entry is reported as a
+// *BatchError naming its key; a batch that does not fit as a whole returns
+// ErrBatchTooLarge, or ErrCacheFull when the full policy forbids evicting.
+// BlockPolicy does not wait, since waiting would release the lock.
+func (c *LRUCache) PutAllOrNothing(entries map[string]string) error {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type pending struct {
+		original, key, value string
+		cost                 int64
+	}
+	batch := make([]pending, 0, len(keys))
+	index := make(map[string]int, len(keys))
+	for _, key := range keys {
+		normalized := c.normalizeKey(key)
+		e := pending{original: key, key: normalized, value: entries[key], cost: entryCost(normalized, entries[key], nil)}
+		if i, ok := index[normalized]; ok {
+			batch[i] = e // the last of the keys normalizing alike wins
+			continue
+		}
+		index[normalized] = len(batch)
+		batch = append(batch, e)
+	}
+
+	c.mutex.Lock()
+	defer c.unlock()
+
+	var total, added int64
+	var inserts int
+	for _, e := range batch {
+		if err := c.checkEntry(e.key, e.cost); err != nil {
+			return &BatchError{Key: e.original, Err: err}
+		}
+		total += e.cost
+		if node, ok := c.Cache[e.key]; ok {
+			added += e.cost - node.cost
+		} else {
+			added += e.cost
+			inserts++
+		}
+	}
+	if (c.Capacity != NoLimit && len(batch) > c.Capacity) || (c.maxBytes > 0 && total > c.maxBytes) {
+		return ErrBatchTooLarge
+	}
+	if c.onFull.kind != fullEvict && c.overflows(inserts, added) {
+		if c.reclaimExpired() == 0 || c.overflows(inserts, added) {
+			return ErrCacheFull
+		}
+	}
+
+	for _, e := range batch {
+		c.store(e.key, e.value, nil, c.ttl, e.cost)
+		c.recordEvent("put", e.key, "stored")
+	}
+	return nil
+}
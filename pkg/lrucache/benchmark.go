@@ -0,0 +1,165 @@
+package lrucache
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Distribution selects how Benchmark picks keys from the synthetic keyspace.
+type Distribution int
+
+const (
+	// Uniform picks every key with the same probability.
+	Uniform Distribution = iota
+	// Zipfian picks a few hot keys much more often than the rest.
+	Zipfian
+)
+
+// maxLatencySamples bounds the latency samples kept per goroutine.
+const maxLatencySamples = 1 << 16
+
+// BenchConfig configures a synthetic load run.
+type BenchConfig struct {
+	Goroutines   int           // concurrent workers, defaults to GOMAXPROCS
+	Duration     time.Duration // run length; when zero Operations is used instead
+	Operations   int           // total operations across all workers, defaults to 10000
+	Keys         int           // size of the keyspace, defaults to 1000
+	Distribution Distribution  // key distribution, Uniform by default
+	ZipfS        float64       // Zipfian skew, must be > 1, defaults to 1.1
+	WriteRatio   float64       // fraction of operations that are plain Puts
+	ValueSize    int           // size of the stored values in bytes, defaults to 64
+}
+
+// BenchResult summarises a Benchmark run.
+type BenchResult struct {
+	Operations int64
+	Hits       int64
+	Misses     int64
+	HitRate    float64 // percentage of Gets that hit
+	Elapsed    time.Duration
+	Throughput float64 // operations per second
+	P50        time.Duration
+	P99        time.Duration
+}
+
+// Benchmark drives synthetic Get/Put traffic against cache without any network
+// involved, so capacities and policies can be compared on equal footing.
+// Reads behave like a read-through cache: a Get miss is followed by a Put.
+func Benchmark(cache Cache, cfg BenchConfig) BenchResult {
+	if cfg.Goroutines <= 0 {
+		cfg.Goroutines = runtime.GOMAXPROCS(0)
+	}
+	if cfg.Duration <= 0 && cfg.Operations <= 0 {
+		cfg.Operations = 10000
+	}
+	if cfg.Keys <= 0 {
+		cfg.Keys = 1000
+	}
+	if cfg.ZipfS <= 1 {
+		cfg.ZipfS = 1.1
+	}
+	if cfg.ValueSize <= 0 {
+		cfg.ValueSize = 64
+	}
+
+	value := strings.Repeat("x", cfg.ValueSize)
+	deadline := time.Now().Add(cfg.Duration)
+
+	var ops, hits, misses int64
+	samples := make([][]time.Duration, cfg.Goroutines)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for g := range cfg.Goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(g)))
+			var zipf *rand.Zipf
+			if cfg.Distribution == Zipfian {
+				zipf = rand.NewZipf(r, cfg.ZipfS, 1, uint64(cfg.Keys-1))
+			}
+
+			local := make([]time.Duration, 0, 1024)
+			for n := 0; ; n++ {
+				if cfg.Duration > 0 {
+					if time.Now().After(deadline) {
+						break
+					}
+				} else if atomic.AddInt64(&ops, 1) > int64(cfg.Operations) {
+					atomic.AddInt64(&ops, -1)
+					break
+				}
+
+				var id int
+				if zipf != nil {
+					id = int(zipf.Uint64())
+				} else {
+					id = r.Intn(cfg.Keys)
+				}
+				key := fmt.Sprintf("key_%d", id)
+
+				opStart := time.Now()
+				if r.Float64() < cfg.WriteRatio {
+					cache.Put(key, value)
+				} else if _, ok := cache.Get(key); ok {
+					atomic.AddInt64(&hits, 1)
+				} else {
+					atomic.AddInt64(&misses, 1)
+					cache.Put(key, value)
+				}
+				elapsed := time.Since(opStart)
+
+				// Reservoir sampling keeps the latency sample bounded
+				if len(local) < maxLatencySamples {
+					local = append(local, elapsed)
+				} else if j := r.Intn(n + 1); j < maxLatencySamples {
+					local[j] = elapsed
+				}
+				if cfg.Duration > 0 {
+					atomic.AddInt64(&ops, 1)
+				}
+			}
+			samples[g] = local
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	var all []time.Duration
+	for _, s := range samples {
+		all = append(all, s...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	result := BenchResult{
+		Operations: ops,
+		Hits:       hits,
+		Misses:     misses,
+		Elapsed:    elapsed,
+		P50:        percentile(all, 0.50),
+		P99:        percentile(all, 0.99),
+	}
+	if total := hits + misses; total > 0 {
+		result.HitRate = float64(hits) / float64(total) * 100
+	}
+	if elapsed > 0 {
+		result.Throughput = float64(ops) / elapsed.Seconds()
+	}
+	return result
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(float64(len(sorted)-1)*p)]
+}
@@ -0,0 +1,25 @@
+package lrucache
+
+// Variants of the core methods for binary keys such as raw hashes.
+// The byte slice is copied into a string, so callers may reuse it afterwards,
+// and any byte value, including NUL, is preserved.
+
+// GetB is like Get with a binary key.
+func (c *LRUCache) GetB(key []byte) (string, bool) {
+	return c.Get(string(key))
+}
+
+// PutB is like Put with a binary key.
+func (c *LRUCache) PutB(key []byte, value string) {
+	c.Put(string(key), value)
+}
+
+// DeleteB is like Delete with a binary key.
+func (c *LRUCache) DeleteB(key []byte) bool {
+	return c.Delete(string(key))
+}
+
+// HasB is like Has with a binary key.
+func (c *LRUCache) HasB(key []byte) bool {
+	return c.Has(string(key))
+}
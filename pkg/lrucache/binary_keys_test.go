@@ -0,0 +1,39 @@
+package lrucache
+
+import "testing"
+
+func TestBinaryKeysWithNulBytes(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	keys := [][]byte{
+		{0x00},
+		{0x00, 0x00},
+		{0xde, 0xad, 0x00, 0xbe, 0xef},
+		{0xde, 0xad, 0x00, 0xbe},
+	}
+	for i, key := range keys {
+		c.PutB(key, string(rune('a'+i)))
+	}
+
+	key := keys[2]
+	c.PutB(key, "updated")
+	key[0] = 0xff // the cache keeps its own copy
+	for i, key := range keys {
+		want := string(rune('a' + i))
+		if i == 2 {
+			key = []byte{0xde, 0xad, 0x00, 0xbe, 0xef}
+			want = "updated"
+		}
+		if got, ok := c.GetB(key); !ok || got != want {
+			t.Fatalf("GetB(%x) = %q, %v, want %q", key, got, ok, want)
+		}
+	}
+	if c.Size() != len(keys) {
+		t.Fatalf("Size() = %d, want %d distinct keys", c.Size(), len(keys))
+	}
+	if c.HasB([]byte{0x00, 0x00, 0x00}) || c.HasB(nil) {
+		t.Fatal("HasB found a key that was never put")
+	}
+	if !c.DeleteB([]byte{0x00}) || c.HasB([]byte{0x00}) || !c.HasB([]byte{0x00, 0x00}) {
+		t.Fatal("DeleteB removed the wrong key")
+	}
+}
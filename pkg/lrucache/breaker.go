@@ -0,0 +1,71 @@
+package lrucache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLoaderBreakerOpen is returned by GetOrLoad while the loader circuit
+// breaker is open.
+var ErrLoaderBreakerOpen = errors.New("loader circuit breaker is open")
+
+// WithLoaderCircuitBreaker stops calling a failing loader. After threshold
+// consecutive loader errors GetOrLoad fails fast with ErrLoaderBreakerOpen for
+// cooldown. Then the breaker half-opens: the next miss calls the loader again,
+// closing the breaker on success and reopening it for another cooldown on error,
+// while other misses keep failing fast. Cache hits are served throughout.
+// A threshold of zero or less disables the breaker.
+func WithLoaderCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *LRUCache) {
+		if threshold <= 0 {
+			c.breaker = nil
+			return
+		}
+		c.breaker = &breaker{threshold: threshold, cooldown: cooldown}
+	}
+}
+
+// breaker is a consecutive-failure circuit breaker.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mutex    sync.Mutex
+	failures int
+	openedAt time.Time // zero while closed
+	trial    bool      // a half-open trial call is in flight
+}
+
+// allow reports whether a loader call may go ahead at now.
+func (b *breaker) allow(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || now.Before(b.openedAt.Add(b.cooldown)) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// done records the outcome of a loader call that allow let through.
+func (b *breaker) done(err error, now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.trial = false
+	if err == nil {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = now
+	}
+}
@@ -0,0 +1,107 @@
+package lrucache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoaderCircuitBreaker(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	errUpstream := errors.New("upstream down")
+	failing := true
+	calls := 0
+	c, _ := NewLRUCacheWithOptions(10,
+		WithClock(func() time.Time { return now }),
+		WithLoaderCircuitBreaker(3, time.Minute),
+		WithLoader(func(key string) (string, error) {
+			calls++
+			if failing {
+				return "", errUpstream
+			}
+			return "v-" + key, nil
+		}),
+	)
+	c.Put("cached", "v")
+
+	// Closed: errors pass through until the threshold is reached.
+	for i := range 3 {
+		if _, err := c.GetOrLoad("k"); !errors.Is(err, errUpstream) {
+			t.Fatalf("load %d error = %v, want the loader error", i, err)
+		}
+	}
+
+	// Open: loads fail fast without calling the loader, hits are still served.
+	if _, err := c.GetOrLoad("k"); !errors.Is(err, ErrLoaderBreakerOpen) {
+		t.Fatalf("error = %v, want ErrLoaderBreakerOpen", err)
+	}
+	if value, err := c.GetOrLoad("cached"); err != nil || value != "v" {
+		t.Fatalf("GetOrLoad(cached) = %q, %v, want the cached value", value, err)
+	}
+	if calls != 3 {
+		t.Fatalf("loader called %d times, want 3", calls)
+	}
+
+	// Half-open after the cooldown: one failed trial reopens it.
+	now = now.Add(time.Minute)
+	if _, err := c.GetOrLoad("k"); !errors.Is(err, errUpstream) {
+		t.Fatalf("trial error = %v, want the loader error", err)
+	}
+	if _, err := c.GetOrLoad("k"); !errors.Is(err, ErrLoaderBreakerOpen) {
+		t.Fatalf("error after a failed trial = %v, want ErrLoaderBreakerOpen", err)
+	}
+	if calls != 4 {
+		t.Fatalf("loader called %d times, want 4", calls)
+	}
+
+	// A successful trial closes it and resets the failure count.
+	now = now.Add(time.Minute)
+	failing = false
+	if value, err := c.GetOrLoad("k"); err != nil || value != "v-k" {
+		t.Fatalf("trial = %q, %v, want v-k", value, err)
+	}
+	failing = true
+	for i := range 2 {
+		if _, err := c.GetOrLoad("x"); !errors.Is(err, errUpstream) {
+			t.Fatalf("load %d after closing error = %v, want the loader error", i, err)
+		}
+	}
+	failing = false
+	if _, err := c.GetOrLoad("y"); err != nil {
+		t.Fatalf("load below the threshold = %v, want success", err)
+	}
+}
+
+func TestLoaderCircuitBreakerSingleTrial(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	c, _ := NewLRUCacheWithOptions(10,
+		WithClock(func() time.Time { return now }),
+		WithLoaderCircuitBreaker(1, time.Minute),
+		WithLoader(func(key string) (string, error) {
+			if key == "trial" {
+				started <- struct{}{}
+				<-release
+				return "ok", nil
+			}
+			return "", errors.New("down")
+		}),
+	)
+	c.GetOrLoad("fail")
+	now = now.Add(time.Minute)
+
+	trial := make(chan error)
+	go func() {
+		_, err := c.GetOrLoad("trial")
+		trial <- err
+	}()
+	<-started
+	if _, err := c.GetOrLoad("other"); !errors.Is(err, ErrLoaderBreakerOpen) {
+		t.Fatalf("load during the trial = %v, want ErrLoaderBreakerOpen", err)
+	}
+	close(release)
+	if err := <-trial; err != nil {
+		t.Fatalf("trial = %v, want success", err)
+	}
+}
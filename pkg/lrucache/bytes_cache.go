@@ -0,0 +1,190 @@
+package lrucache
+
+import "sync"
+
+// BytesLRUCache is an LRU cache storing []byte values, the natural type for HTTP
+// response bodies, images or serialized protobufs. It avoids the string(body)
+// and []byte(value) copies the string-valued LRUCache needs.
+//
+// Put takes ownership of the slice it is given and Get returns the stored slice
+// itself, so callers must not modify either of them.
+type BytesLRUCache struct {
+	capacity    int
+	head        *bytesNode
+	tail        *bytesNode
+	cache       map[string]*bytesNode
+	mutex       sync.Mutex
+	secureErase bool
+}
+
+type bytesNode struct {
+	key   string
+	value []byte
+	prev  *bytesNode
+	next  *bytesNode
+}
+
+// BytesOption configures a BytesLRUCache.
+type BytesOption func(*BytesLRUCache)
+
+// WithBytesSecureErase zeroes the backing array of every value that is evicted,
+// deleted or cleared, so secrets do not linger in memory. In this mode Get
+// returns a copy of the value, since the stored slice is wiped on removal.
+func WithBytesSecureErase(enabled bool) BytesOption {
+	return func(c *BytesLRUCache) {
+		c.secureErase = enabled
+	}
+}
+
+// NewBytesLRUCache creates a new BytesLRUCache Instance with the specified capacity.
+func NewBytesLRUCache(capacity int, opts ...BytesOption) (*BytesLRUCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	c := &BytesLRUCache{
+		capacity: capacity,
+		cache:    make(map[string]*bytesNode),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Get retrieves the value for a given key from the cache.
+func (c *BytesLRUCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	c.moveToHead(node)
+
+	if c.secureErase {
+		return append([]byte(nil), node.value...), true
+	}
+	return node.value, true
+}
+
+// Put adds a key-value pair to the cache, evicting the least recently used
+// entry when the cache is full.
+func (c *BytesLRUCache) Put(key string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if node, ok := c.cache[key]; ok {
+		c.wipe(node.value)
+		node.value = value
+		c.moveToHead(node)
+		return
+	}
+
+	if len(c.cache) >= c.capacity && c.tail != nil {
+		c.remove(c.tail)
+	}
+
+	node := &bytesNode{key: key, value: value}
+	c.cache[key] = node
+	c.addToHead(node)
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *BytesLRUCache) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	c.remove(node)
+	return true
+}
+
+// Has checks if the cache contains a specific key.
+func (c *BytesLRUCache) Has(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Size returns the current number of items in the cache.
+func (c *BytesLRUCache) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.cache)
+}
+
+// Clear removes all items from the cache.
+func (c *BytesLRUCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for node := c.head; node != nil; node = node.next {
+		c.wipe(node.value)
+	}
+	c.head = nil
+	c.tail = nil
+	c.cache = make(map[string]*bytesNode)
+}
+
+// remove unlinks a node, drops it from the map and wipes its value.
+func (c *BytesLRUCache) remove(node *bytesNode) {
+	c.unlink(node)
+	delete(c.cache, node.key)
+	c.wipe(node.value)
+	node.value = nil
+}
+
+// wipe zeroes a value in secure erase mode.
+func (c *BytesLRUCache) wipe(value []byte) {
+	if c.secureErase {
+		clear(value)
+	}
+}
+
+func (c *BytesLRUCache) moveToHead(node *bytesNode) {
+	if c.head == node {
+		return
+	}
+	c.unlink(node)
+	c.addToHead(node)
+}
+
+// unlink removes a node from the doubly linked list.
+func (c *BytesLRUCache) unlink(node *bytesNode) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		c.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		c.tail = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+// addToHead adds a node to the head of the doubly linked list.
+func (c *BytesLRUCache) addToHead(node *bytesNode) {
+	node.prev = nil
+	node.next = c.head
+
+	if c.head != nil {
+		c.head.prev = node
+	}
+	c.head = node
+
+	if c.tail == nil {
+		c.tail = node
+	}
+}
@@ -0,0 +1,13 @@
+package lrucache
+
+// Cache is the common interface implemented by the caches in this package.
+type Cache interface {
+	Get(key string) (string, bool)
+	Put(key string, value string)
+	Has(key string) bool
+	Delete(key string) bool
+	Clear()
+	Size() int
+}
+
+var _ Cache = (*LRUCache)(nil)
@@ -0,0 +1,127 @@
+package lrucache
+
+import "sync/atomic"
+
+// Chain layers several caches into a fallback chain, e.g. a tiny per-request
+// cache in front of a process LRU in front of a remote cache.
+// Level 0 is consulted first.
+type Chain struct {
+	levels    []chainLevel
+	firstOnly atomic.Bool // Put writes only to level 0
+	misses    atomic.Int64
+}
+
+type chainLevel struct {
+	cache      Cache
+	noBackfill atomic.Bool
+	hits       atomic.Int64
+}
+
+// ChainStats reports which level satisfied each Get.
+type ChainStats struct {
+	LevelHits []int64 // hits per level, indexed like the caches passed to NewChain
+	Misses    int64   // Gets that no level could answer
+}
+
+var _ Cache = (*Chain)(nil)
+
+// NewChain creates a chain over caches, ordered from the fastest to the slowest level.
+// By default every level is backfilled on a hit further down and Put writes to all levels.
+func NewChain(caches ...Cache) *Chain {
+	chain := &Chain{levels: make([]chainLevel, len(caches))}
+	for i, cache := range caches {
+		chain.levels[i].cache = cache
+	}
+	return chain
+}
+
+// SetBackfill controls whether a level is filled when a slower level answers a Get.
+func (ch *Chain) SetBackfill(level int, enabled bool) {
+	ch.levels[level].noBackfill.Store(!enabled)
+}
+
+// SetWriteFirstOnly makes Put write only to the first level instead of all of them.
+func (ch *Chain) SetWriteFirstOnly(enabled bool) {
+	ch.firstOnly.Store(enabled)
+}
+
+// Get tries each level in order and backfills the faster levels on a hit.
+func (ch *Chain) Get(key string) (string, bool) {
+	for i := range ch.levels {
+		level := &ch.levels[i]
+		value, ok := level.cache.Get(key)
+		if !ok {
+			continue
+		}
+
+		level.hits.Add(1)
+		for j := 0; j < i; j++ {
+			if !ch.levels[j].noBackfill.Load() {
+				ch.levels[j].cache.Put(key, value)
+			}
+		}
+		return value, true
+	}
+
+	ch.misses.Add(1)
+	return "", false
+}
+
+// Put writes to every level, or only to the first one if SetWriteFirstOnly is on.
+func (ch *Chain) Put(key string, value string) {
+	for i := range ch.levels {
+		ch.levels[i].cache.Put(key, value)
+		if ch.firstOnly.Load() {
+			return
+		}
+	}
+}
+
+// Delete removes the key from every level.
+// Returns true if any level held it.
+func (ch *Chain) Delete(key string) bool {
+	deleted := false
+	for i := range ch.levels {
+		if ch.levels[i].cache.Delete(key) {
+			deleted = true
+		}
+	}
+	return deleted
+}
+
+// Has checks if any level contains the key.
+func (ch *Chain) Has(key string) bool {
+	for i := range ch.levels {
+		if ch.levels[i].cache.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear removes all items from every level.
+func (ch *Chain) Clear() {
+	for i := range ch.levels {
+		ch.levels[i].cache.Clear()
+	}
+}
+
+// Size returns the number of items in the first level.
+func (ch *Chain) Size() int {
+	if len(ch.levels) == 0 {
+		return 0
+	}
+	return ch.levels[0].cache.Size()
+}
+
+// Stats returns the per-level hit counters.
+func (ch *Chain) Stats() ChainStats {
+	stats := ChainStats{
+		LevelHits: make([]int64, len(ch.levels)),
+		Misses:    ch.misses.Load(),
+	}
+	for i := range ch.levels {
+		stats.LevelHits[i] = ch.levels[i].hits.Load()
+	}
+	return stats
+}
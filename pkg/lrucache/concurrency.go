@@ -0,0 +1,247 @@
+package lrucache
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// locker is the synchronization behind an LRUCache, selected with WithConcurrency.
+// Lookups that relink the list take the write lock; Has, Size, Stats and
+// the other read-only methods take the read lock.
+type locker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+// Strategy selects how an LRUCache synchronizes concurrent callers.
+type Strategy struct {
+	shards      int  // zero for a plain sync.RWMutex
+	atomicReads bool // serve lookups from a published snapshot
+}
+
+// StrategyMutex guards the cache with a single sync.RWMutex. This is the default.
+var StrategyMutex = Strategy{}
+
+// StrategySharded spreads the reader count of the lock over n padded counters,
+// so concurrent read-only calls do not all contend on one cache line. Writers
+// sum the counters, so each write lock costs O(n). Get relinks its entry and
+// still takes the write lock, so this only pays off when Has, Size, Stats and
+// similar calls make up nearly all of the traffic; see BenchmarkConcurrency.
+// An n below 1 uses GOMAXPROCS counters.
+func StrategySharded(n int) Strategy {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	return Strategy{shards: n}
+}
+
+// StrategyAtomicReads lets Get and the other lookups read a snapshot of the
+// entries, published through an atomic pointer, instead of taking the lock.
+// Their bookkeeping (promotion, hit and miss counts, events) is buffered and
+// applied by the next caller that takes the write lock before it changes
+// anything, so evictions still see every lookup; read-only calls such as
+// Stats can lag behind by up to readBufferSize lookups. Every write drops the
+// snapshot and the next lookup rebuilds it under the write lock, copying
+// every entry, so this only pays off when writes are rare. Lookups of expired
+// entries take the lock as usual; see BenchmarkConcurrency.
+var StrategyAtomicReads = Strategy{atomicReads: true}
+
+// WithConcurrency selects the synchronization strategy. The public API and its
+// guarantees are the same under every strategy, apart from the lagging
+// read-only calls under StrategyAtomicReads; compare them for a given read
+// and write mix with Benchmark.
+func WithConcurrency(s Strategy) Option {
+	return func(c *LRUCache) {
+		c.mutex = s.locker()
+		c.reads, _ = c.mutex.(*readLock)
+		if c.reads != nil {
+			c.reads.apply = c.applyReadHits
+		}
+	}
+}
+
+func (s Strategy) locker() locker {
+	if s.atomicReads {
+		return &readLock{hits: make(chan readHit, readBufferSize)}
+	}
+	if s.shards == 0 {
+		return new(sync.RWMutex)
+	}
+	return &shardedLock{readers: make([]paddedCounter, s.shards)}
+}
+
+// shardedLock is a reader-writer lock with one reader counter per shard.
+// A reader increments a random counter and RUnlock decrements another random
+// one; only the sum is meaningful. A writer raises the writing flag and waits
+// for the sum to reach zero. Readers that see the flag back off and park on
+// the writer mutex until the writer is done.
+type shardedLock struct {
+	writer  sync.Mutex // serializes writers and parks readers while one is active
+	writing atomic.Bool
+	readers []paddedCounter
+}
+
+// paddedCounter keeps each counter on its own cache line.
+type paddedCounter struct {
+	n atomic.Int64
+	_ [56]byte
+}
+
+func (l *shardedLock) RLock() {
+	for {
+		counter := &l.readers[rand.IntN(len(l.readers))].n
+		counter.Add(1)
+		if !l.writing.Load() {
+			return
+		}
+		counter.Add(-1)
+
+		// Wait for the writer to finish
+		l.writer.Lock()
+		l.writer.Unlock()
+	}
+}
+
+func (l *shardedLock) RUnlock() {
+	l.readers[rand.IntN(len(l.readers))].n.Add(-1)
+}
+
+func (l *shardedLock) Lock() {
+	l.writer.Lock()
+	l.writing.Store(true)
+	for l.active() != 0 {
+		runtime.Gosched()
+	}
+}
+
+func (l *shardedLock) Unlock() {
+	l.writing.Store(false)
+	l.writer.Unlock()
+}
+
+// active returns the number of readers holding the lock. Readers that have
+// not yet seen the writing flag may be counted too, which only delays the writer.
+func (l *shardedLock) active() int64 {
+	var n int64
+	for i := range l.readers {
+		n += l.readers[i].n.Load()
+	}
+	return n
+}
+
+// readBufferSize is how many snapshot lookups StrategyAtomicReads buffers
+// before one of them takes the lock to apply them.
+const readBufferSize = 1024
+
+// readLock is the lock behind StrategyAtomicReads: a sync.RWMutex whose
+// write lock drops the snapshot and applies the buffered lookups.
+type readLock struct {
+	sync.RWMutex
+	snapshot atomic.Pointer[map[string]*readEntry] // nil after a write
+	hits     chan readHit
+	apply    func() // applies the buffered lookups to the cache
+}
+
+// readEntry is a node as of the last snapshot: a copy to read from, and the
+// live node to apply the lookup to later.
+type readEntry struct {
+	view Node
+	node *Node
+}
+
+// readHit is a buffered snapshot lookup of key. node is nil for a miss.
+type readHit struct {
+	key  string
+	node *Node
+}
+
+// Lock takes the write lock, drops the snapshot since the caller may change
+// any entry, and applies the buffered lookups.
+func (l *readLock) Lock() {
+	l.RWMutex.Lock()
+	l.snapshot.Store(nil)
+	if l.apply != nil {
+		l.apply()
+	}
+}
+
+// readSnapshot looks up an already normalized key in the snapshot, without
+// the lock. served is false if there is no snapshot or the entry has expired,
+// and the caller must look the key up under the lock instead.
+func (c *LRUCache) readSnapshot(key string) (node Node, ok, served bool) {
+	snapshot := c.reads.snapshot.Load()
+	if snapshot == nil {
+		return Node{}, false, false
+	}
+
+	hit := readHit{key: key}
+	entry, ok := (*snapshot)[key]
+	if ok {
+		if c.expired(&entry.view) {
+			return Node{}, false, false
+		}
+		hit.node = entry.node
+	}
+
+	select {
+	case c.reads.hits <- hit:
+	default:
+		// The buffer is full: apply it and this lookup. Taking the embedded
+		// lock keeps the snapshot, as applying lookups changes no entry.
+		c.reads.RWMutex.Lock()
+		c.applyReadHits()
+		c.applyReadHit(hit)
+		c.reads.RWMutex.Unlock()
+	}
+	if !ok {
+		return Node{}, false, true
+	}
+	return entry.view, true, true
+}
+
+// publishReads builds a new snapshot for StrategyAtomicReads.
+// The caller must hold the write lock.
+func (c *LRUCache) publishReads() {
+	snapshot := make(map[string]*readEntry, len(c.Cache))
+	for key, node := range c.Cache {
+		if node.deleted {
+			continue
+		}
+		view := *node
+		view.Prev, view.Next = nil, nil
+		snapshot[key] = &readEntry{view: view, node: node}
+	}
+	c.reads.snapshot.Store(&snapshot)
+}
+
+// applyReadHits applies the buffered snapshot lookups.
+// The caller must hold the write lock.
+func (c *LRUCache) applyReadHits() {
+	for {
+		select {
+		case hit := <-c.reads.hits:
+			c.applyReadHit(hit)
+		default:
+			return
+		}
+	}
+}
+
+// applyReadHit records a snapshot lookup and promotes the node it found, if
+// that is still the live entry for the key. It removes nothing, so the
+// snapshot stays valid. The caller must hold the write lock.
+func (c *LRUCache) applyReadHit(hit readHit) {
+	switch {
+	case hit.node == nil:
+		c.recordLookup(hit.key, false)
+		c.tuneMiss(hit.key)
+	case c.Cache[hit.key] == hit.node && c.live(hit.node):
+		c.hit(hit.key, hit.node, true)
+	default:
+		c.recordLookup(hit.key, true)
+	}
+}
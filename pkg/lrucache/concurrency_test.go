@@ -0,0 +1,161 @@
+package lrucache
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestShardedLockExcludesWriters(t *testing.T) {
+	l := StrategySharded(4).locker()
+	var writers, readers atomic.Int32
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 2000 {
+				if i%2 == 0 {
+					l.Lock()
+					if writers.Add(1) != 1 || readers.Load() != 0 {
+						t.Error("writer shares the lock")
+					}
+					writers.Add(-1)
+					l.Unlock()
+				} else {
+					l.RLock()
+					readers.Add(1)
+					if writers.Load() != 0 {
+						t.Error("reader shares the lock with a writer")
+					}
+					readers.Add(-1)
+					l.RUnlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAtomicReadsApplyBufferedLookups(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithConcurrency(StrategyAtomicReads))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Get("a") // takes the lock and publishes the snapshot
+
+	if c.reads.snapshot.Load() == nil {
+		t.Fatal("no snapshot after a locked lookup")
+	}
+	if value, _ := c.Get("b"); value != "2" {
+		t.Fatalf("Get(b) = %q, want 2", value)
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get(missing) hit")
+	}
+	if c.reads.snapshot.Load() == nil {
+		t.Fatal("snapshot lookups dropped the snapshot")
+	}
+
+	// The buffered hit on b is applied before the Put evicts
+	c.Put("c", "3")
+	if c.Has("a") || !c.Has("b") {
+		t.Fatalf("keys = %v, want the buffered hit to save b", listKeys(c))
+	}
+	if s := c.Stats(); s.Hits != 2 || s.Misses != 1 {
+		t.Fatalf("hits, misses = %d, %d, want 2, 1", s.Hits, s.Misses)
+	}
+
+	c.Put("b", "4")
+	if value, _ := c.Get("b"); value != "4" {
+		t.Fatalf("Get(b) after Put = %q, want 4", value)
+	}
+	checkIntegrity(t, c)
+}
+
+func TestAtomicReadsFullBuffer(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(4, WithConcurrency(StrategyAtomicReads))
+	c.Put("a", "1")
+	c.Get("a")
+	for range 3 * readBufferSize {
+		c.Get("a")
+	}
+	c.Put("b", "2")
+	if got := c.Stats().Hits; got != 3*readBufferSize+1 {
+		t.Fatalf("hits = %d, want %d", got, 3*readBufferSize+1)
+	}
+}
+
+// Run with -race: lookups read the snapshot while writers replace it.
+func TestAtomicReadsConcurrent(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(50, WithConcurrency(StrategyAtomicReads))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 2000 {
+				key := strconv.Itoa(j % 100)
+				if i == 0 && j%10 == 0 {
+					c.Put(key, key)
+				} else if value, ok := c.Get(key); ok && value != key {
+					t.Errorf("Get(%s) = %q", key, value)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	checkIntegrity(t, c)
+}
+
+// BenchmarkConcurrency compares the lock strategies from GOMAXPROCS
+// goroutines on read-only, read-heavy (9 reads per Put) and write-heavy
+// (9 Put per read) mixes, reading with Has and with Get. Get takes the write
+// lock except under StrategyAtomicReads.
+func BenchmarkConcurrency(b *testing.B) {
+	strategies := []struct {
+		name     string
+		strategy Strategy
+	}{
+		{"mutex", StrategyMutex},
+		{"sharded", StrategySharded(0)},
+		{"atomic-reads", StrategyAtomicReads},
+	}
+	reads := []struct {
+		name string
+		read func(c *LRUCache, key string)
+	}{
+		{"has", func(c *LRUCache, key string) { c.Has(key) }},
+		{"get", func(c *LRUCache, key string) { c.Get(key) }},
+	}
+	const size = 10_000
+	keys := benchKeys(size)
+
+	for _, writesPer10 := range []int{0, 1, 9} {
+		for _, r := range reads {
+			for _, s := range strategies {
+				b.Run("writes="+strconv.Itoa(writesPer10*10)+"%/"+r.name+"/"+s.name, func(b *testing.B) {
+					c, _ := NewLRUCacheWithOptions(size, WithConcurrency(s.strategy))
+					for _, key := range keys {
+						c.Put(key, key)
+					}
+					var next atomic.Uint64
+					b.ReportAllocs()
+					b.ResetTimer()
+					b.RunParallel(func(pb *testing.PB) {
+						i := int(next.Add(1)) * 7919
+						for pb.Next() {
+							key := keys[i%size]
+							if i%10 < writesPer10 {
+								c.Put(key, key)
+							} else {
+								r.read(c, key)
+							}
+							i++
+						}
+					})
+				})
+			}
+		}
+	}
+}
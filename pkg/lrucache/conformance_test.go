@@ -0,0 +1,91 @@
+package lrucache_test
+
+import (
+	"testing"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache/lrucachetest"
+)
+
+// Every in-tree Cache implementation runs the shared contract here, so a
+// regression in one of them shows up the same way as in LRUCache. Layered
+// caches are configured so the contract applies: one set, one tier or one
+// node of the full capacity.
+
+// must returns c, failing the test if err is set.
+func must[C lrucache.Cache](t *testing.T, c C, err error) lrucache.Cache {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestLRUCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLRUCache(capacity)
+		return must(t, c, err)
+	})
+}
+
+func TestLRUCacheWithReaperConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLRUCacheWithOptions(capacity, lrucache.WithExpirationReaper())
+		if err == nil {
+			t.Cleanup(func() { c.Close() })
+		}
+		return must(t, c, err)
+	})
+}
+
+func TestLRUCacheWithAtomicReadsConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLRUCacheWithOptions(capacity, lrucache.WithConcurrency(lrucache.StrategyAtomicReads))
+		return must(t, c, err)
+	})
+}
+
+func TestPriorityCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewPriorityCache(capacity)
+		return must(t, c, err)
+	})
+}
+
+func TestLFUCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLFUCache(capacity)
+		return must(t, c, err)
+	})
+}
+
+func TestSetAssociativeCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewSetAssociativeCache(1, capacity)
+		return must(t, c, err)
+	})
+}
+
+func TestChainConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLRUCache(capacity)
+		return lrucache.NewChain(must(t, c, err))
+	})
+}
+
+func TestDistributedLRUClientConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewLRUCache(capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lrucache.NewDistributedLRUClient([]*lrucache.LRUCache{c})
+	})
+}
+
+func TestSignedCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewSignedCache(capacity, []byte("conformance secret"))
+		return must(t, c, err)
+	})
+}
@@ -0,0 +1,225 @@
+package lrucache
+
+import (
+	"container/heap"
+	"html/template"
+	"net/http"
+	"slices"
+)
+
+// recentEvictionsSize is how many evictions the dashboard can show.
+const recentEvictionsSize = 20
+
+// dashboardTemplate renders the dashboard without any JavaScript.
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>LRU cache</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+.bar { background: #eee; width: 400px; height: 20px; }
+.fill { background: #4a90d9; height: 100%; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>LRU cache</h1>
+
+<h2>Size</h2>
+<div class="bar"><div class="fill" style="width: {{.FillPercent}}%"></div></div>
+<p>{{.Stats.Size}} / {{.Stats.Capacity}} entries</p>
+
+<h2>Hit rate</h2>
+<meter min="0" max="100" low="50" high="80" optimum="100" value="{{printf "%.1f" .HitRate}}"></meter>
+<p>{{printf "%.1f" .HitRate}}% ({{.Stats.Hits}} hits, {{.Stats.Misses}} misses, {{.Stats.Evictions}} evictions)</p>
+
+<h2>Look up a key</h2>
+<form method="get">
+<input type="text" name="key" value="{{.Query}}">
+<input type="submit" value="Search">
+</form>
+{{if .Query}}{{if .Found}}<pre>{{.Value}}</pre>{{else}}<p>Not cached.</p>{{end}}{{end}}
+
+<h2>Top keys</h2>
+<table>
+<tr><th>Key</th><th>Accesses</th></tr>
+{{range .HotKeys}}<tr><td>{{.Key}}</td><td>{{.Accesses}}</td></tr>
+{{end}}</table>
+
+<h2>Recent evictions</h2>
+<table>
+<tr><th>Time</th><th>Key</th><th>Reason</th></tr>
+{{range .Evictions}}<tr><td>{{.Time.Format "15:04:05"}}</td><td>{{.Key}}</td><td>{{.Result}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// dashboardData is the view model of the dashboard template.
+type dashboardData struct {
+	Stats       Stats
+	FillPercent float64
+	HitRate     float64
+	HotKeys     []HotKey
+	Evictions   []EventRecord
+	Query       string
+	Found       bool
+	Value       string
+}
+
+// NewDashboardHandler serves a self-refreshing HTML dashboard for cache showing
+// its fill level, hit rate, most accessed keys and recent evictions, plus a
+// search box to look up a key without promoting it.
+func NewDashboardHandler(cache *LRUCache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := dashboardData{
+			Stats: cache.Stats(),
+			Query: r.URL.Query().Get("key"),
+		}
+		if data.Stats.Capacity > 0 {
+			data.FillPercent = float64(data.Stats.Size) / float64(data.Stats.Capacity) * 100
+		}
+		if total := data.Stats.Hits + data.Stats.Misses; total > 0 {
+			data.HitRate = float64(data.Stats.Hits) / float64(total) * 100
+		}
+
+		cache.mutex.RLock()
+		data.HotKeys = cache.hotKeys(10)
+		data.Evictions = cache.recentEvictions()
+		if data.Query != "" {
+			if node, ok := cache.Cache[cache.normalizeKey(data.Query)]; ok && cache.live(node) {
+				data.Found, data.Value = true, node.Value
+			}
+		}
+		cache.mutex.RUnlock()
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// HotKey is a key with its access count.
+type HotKey struct {
+	Key      string
+	Accesses uint64
+}
+
+// KeyStats returns how many times key was hit since it was inserted.
+// The count starts over when an entry is evicted and inserted again.
+func (c *LRUCache) KeyStats(key string) (accesses uint64, ok bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	node, ok := c.Cache[c.normalizeKey(key)]
+	if !ok || !c.live(node) {
+		return 0, false
+	}
+	return node.accesses, true
+}
+
+// HotKeys returns the n most accessed current entries, most accessed first,
+// with ties going to the most recently used. It returns nil for n <= 0.
+// Only n entries are kept while scanning, so the cost under the read lock
+// is O(size log n) time and O(n) memory.
+func (c *LRUCache) HotKeys(n int) []HotKey {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.hotKeys(n)
+}
+
+// hotKeys returns the n most accessed entries, most accessed first.
+// The caller must hold at least the read lock.
+func (c *LRUCache) hotKeys(n int) []HotKey {
+	if n <= 0 {
+		return nil
+	}
+
+	// Walking from the head, a later entry only replaces the coldest one kept
+	// when it has strictly more accesses, so ties keep the more recent entry.
+	top := make(hotKeyHeap, 0, min(n, len(c.Cache)))
+	seq := 0
+	for node := c.Head; node != nil; node = node.Next {
+		if !c.live(node) {
+			continue
+		}
+		rank := hotKeyRank{HotKey{Key: node.Key, Accesses: node.accesses}, seq}
+		seq++
+		switch {
+		case len(top) < n:
+			heap.Push(&top, rank)
+		case node.accesses > top[0].Accesses:
+			top[0] = rank
+			heap.Fix(&top, 0)
+		}
+	}
+
+	slices.SortFunc(top, func(a, b hotKeyRank) int {
+		if a.less(b) {
+			return 1
+		}
+		return -1
+	})
+	keys := make([]HotKey, len(top))
+	for i, rank := range top {
+		keys[i] = rank.HotKey
+	}
+	return keys
+}
+
+// hotKeyRank is a HotKey candidate with its order of arrival, to break ties.
+type hotKeyRank struct {
+	HotKey
+	seq int
+}
+
+// less reports whether r ranks below o: fewer accesses, or equal accesses
+// and a later position from the head.
+func (r hotKeyRank) less(o hotKeyRank) bool {
+	if r.Accesses != o.Accesses {
+		return r.Accesses < o.Accesses
+	}
+	return r.seq > o.seq
+}
+
+// hotKeyHeap is a min-heap of candidates, the lowest ranked on top.
+type hotKeyHeap []hotKeyRank
+
+func (h hotKeyHeap) Len() int           { return len(h) }
+func (h hotKeyHeap) Less(i, j int) bool { return h[i].less(h[j]) }
+func (h hotKeyHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *hotKeyHeap) Push(x any)        { *h = append(*h, x.(hotKeyRank)) }
+func (h *hotKeyHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// rememberEviction keeps the eviction for the dashboard.
+// The caller must hold the write lock.
+func (c *LRUCache) rememberEviction(key string, reason EvictionReason) {
+	c.recent[c.recentNext%recentEvictionsSize] = EventRecord{
+		Op:     "evict",
+		Key:    key,
+		Result: reason.String(),
+		Time:   c.now(),
+	}
+	c.recentNext++
+}
+
+// recentEvictions returns the last evictions, newest first.
+// The caller must hold at least the read lock.
+func (c *LRUCache) recentEvictions() []EventRecord {
+	n := min(c.recentNext, recentEvictionsSize)
+	evictions := make([]EventRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		evictions = append(evictions, c.recent[(c.recentNext-i)%recentEvictionsSize])
+	}
+	return evictions
+}
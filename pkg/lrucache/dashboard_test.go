@@ -0,0 +1,40 @@
+package lrucache
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestHotKeysRanking(t *testing.T) {
+	c, _ := NewLRUCache(10)
+	for i := range 6 {
+		c.Put("k"+strconv.Itoa(i), "v")
+	}
+	// k3: 5 hits, k1: 3, k4 and k0: 2 each (k0 read last), k2: 1, k5: none
+	for key, hits := range map[string]int{"k3": 5, "k1": 3, "k4": 2, "k2": 1} {
+		for range hits {
+			c.Get(key)
+		}
+	}
+	c.Get("k0")
+	c.Get("k0")
+
+	all := []HotKey{{"k3", 5}, {"k1", 3}, {"k0", 2}, {"k4", 2}, {"k2", 1}, {"k5", 0}}
+	for n := 0; n <= len(all)+2; n++ {
+		want := all[:min(n, len(all))]
+		if got := c.HotKeys(n); !slices.Equal(got, want) && !(n == 0 && got == nil) {
+			t.Fatalf("HotKeys(%d) = %v, want %v", n, got, want)
+		}
+	}
+	if got := c.HotKeys(-1); got != nil {
+		t.Fatalf("HotKeys(-1) = %v, want nil", got)
+	}
+
+	// Counts reset when an entry leaves and comes back.
+	c.Delete("k3")
+	c.Put("k3", "v")
+	if accesses, ok := c.KeyStats("k3"); !ok || accesses != 0 {
+		t.Fatalf("KeyStats(k3) = %d, %v after re-insertion, want 0", accesses, ok)
+	}
+}
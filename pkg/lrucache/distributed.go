@@ -0,0 +1,214 @@
+package lrucache
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// virtualNodes is how many points each cache gets on the hash ring.
+const virtualNodes = 128
+
+// DistributedLRUClient spreads keys over several LRUCache instances with a
+// consistent hash ring, so adding or removing an instance only moves the keys
+// of the affected ring segments. Each instance has its own lock, which lets
+// goroutines working on different keys proceed in parallel. Operations keep
+// the ring read-locked while they run on a node, so none of them can land on
+// a node in the middle of a migration.
+type DistributedLRUClient struct {
+	mutex  sync.RWMutex // guards the ring; held exclusively while keys migrate
+	ring   []ringPoint  // sorted by hash
+	nodes  map[*LRUCache]int
+	nextID int
+}
+
+type ringPoint struct {
+	hash uint32
+	node *LRUCache
+}
+
+var _ Cache = (*DistributedLRUClient)(nil)
+
+// NewDistributedLRUClient creates a client routing keys over nodes.
+// Nil and duplicate nodes are ignored.
+func NewDistributedLRUClient(nodes []*LRUCache) *DistributedLRUClient {
+	d := &DistributedLRUClient{nodes: make(map[*LRUCache]int)}
+	for _, node := range nodes {
+		d.addPoints(node)
+	}
+	return d
+}
+
+// AddNode adds a cache to the ring and migrates the keys it now owns from the
+// other nodes, keeping their relative recency. TTLs are not carried over.
+func (d *DistributedLRUClient) AddNode(c *LRUCache) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.addPoints(c) {
+		return
+	}
+	for node := range d.nodes {
+		if node == c {
+			continue
+		}
+
+		node.mutex.RLock()
+		entries := node.entries()
+		node.mutex.RUnlock()
+
+		// Oldest first, so the most recent entries end up at the head
+		for i := len(entries) - 1; i >= 0; i-- {
+			if d.owner(entries[i].Key) == c {
+				c.Put(entries[i].Key, entries[i].Value)
+				node.Delete(entries[i].Key)
+			}
+		}
+	}
+}
+
+// RemoveNode drops a cache from the ring and migrates its keys to the
+// remaining nodes. The removed cache is left empty. Removing the last node
+// discards its entries.
+func (d *DistributedLRUClient) RemoveNode(c *LRUCache) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.nodes[c]; !ok {
+		return
+	}
+	delete(d.nodes, c)
+	ring := d.ring[:0]
+	for _, point := range d.ring {
+		if point.node != c {
+			ring = append(ring, point)
+		}
+	}
+	d.ring = ring
+
+	for _, entry := range c.Drain() {
+		if owner := d.owner(entry.Key); owner != nil {
+			owner.Put(entry.Key, entry.Value)
+		}
+	}
+}
+
+// Get retrieves the value for a given key from the node that owns it.
+func (d *DistributedLRUClient) Get(key string) (string, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	node := d.owner(key)
+	if node == nil {
+		return "", false
+	}
+	return node.Get(key)
+}
+
+// Put adds a key-value pair to the node that owns the key.
+// It is dropped if the client has no nodes.
+func (d *DistributedLRUClient) Put(key string, value string) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	node := d.owner(key)
+	if node != nil {
+		node.Put(key, value)
+	}
+}
+
+// Delete removes a key from the node that owns it.
+// Returns true if the key was present.
+func (d *DistributedLRUClient) Delete(key string) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	node := d.owner(key)
+	return node != nil && node.Delete(key)
+}
+
+// Has checks if the node owning the key contains it.
+func (d *DistributedLRUClient) Has(key string) bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	node := d.owner(key)
+	return node != nil && node.Has(key)
+}
+
+// Clear removes all items from every node.
+func (d *DistributedLRUClient) Clear() {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	for node := range d.nodes {
+		node.Clear()
+	}
+}
+
+// Size returns the number of items across all nodes.
+func (d *DistributedLRUClient) Size() int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	size := 0
+	for node := range d.nodes {
+		size += node.Size()
+	}
+	return size
+}
+
+// addPoints places the virtual nodes of c on the ring.
+// It reports false if c is nil or already present.
+func (d *DistributedLRUClient) addPoints(c *LRUCache) bool {
+	if c == nil {
+		return false
+	}
+	if _, ok := d.nodes[c]; ok {
+		return false
+	}
+
+	id := d.nextID
+	d.nextID++
+	d.nodes[c] = id
+	for i := 0; i < virtualNodes; i++ {
+		d.ring = append(d.ring, ringPoint{
+			hash: ringHash(strconv.Itoa(id) + "#" + strconv.Itoa(i)),
+			node: c,
+		})
+	}
+	sort.Slice(d.ring, func(i, j int) bool { return d.ring[i].hash < d.ring[j].hash })
+	return true
+}
+
+// owner returns the node owning key: the first ring point at or after its hash.
+// The caller must hold the mutex.
+func (d *DistributedLRUClient) owner(key string) *LRUCache {
+	if len(d.ring) == 0 {
+		return nil
+	}
+
+	h := ringHash(key)
+	i := sort.Search(len(d.ring), func(i int) bool { return d.ring[i].hash >= h })
+	if i == len(d.ring) {
+		i = 0
+	}
+	return d.ring[i].node
+}
+
+// ringHash hashes a key or a virtual node name onto the ring.
+// FNV-1a alone clusters similar short strings, so the result is run through
+// the murmur3 finalizer to spread it over the ring.
+func ringHash(s string) uint32 {
+	f := fnv.New32a()
+	f.Write([]byte(s))
+	h := f.Sum32()
+
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,86 @@
+package lrucache
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Run with -race: nodes join and leave while goroutines write and read, and
+// no write may be lost to a migration.
+func TestDistributedLRUClientConcurrentMembership(t *testing.T) {
+	const (
+		writers = 4
+		keys    = 50
+		rounds  = 20
+	)
+	// Yielding in the normalizer widens the gap between routing a key and
+	// reaching its node, where a migration could otherwise slip in.
+	yield := func(key string) string {
+		runtime.Gosched()
+		return key
+	}
+	newNode := func() *LRUCache {
+		c, _ := NewLRUCacheWithOptions(writers*keys, WithKeyNormalizer(yield))
+		return c
+	}
+	first, spare := newNode(), newNode()
+	d := NewDistributedLRUClient([]*LRUCache{first, newNode()})
+
+	var wg sync.WaitGroup
+	latest := make([][]string, writers)
+	for w := 0; w < writers; w++ {
+		latest[w] = make([]string, keys)
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				for k := 0; k < keys; k++ {
+					key := "w" + strconv.Itoa(w) + "-" + strconv.Itoa(k)
+					value := strconv.Itoa(r)
+					d.Put(key, value)
+					latest[w][k] = value
+					if got, ok := d.Get(key); !ok || got != value {
+						t.Errorf("Get(%q) = %q, %v right after Put(%q)", key, got, ok, value)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			d.AddNode(spare)
+			d.RemoveNode(first)
+			d.AddNode(first)
+			d.RemoveNode(spare)
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-done
+	if t.Failed() {
+		return
+	}
+
+	for w := range latest {
+		for k, want := range latest[w] {
+			key := "w" + strconv.Itoa(w) + "-" + strconv.Itoa(k)
+			if got, ok := d.Get(key); !ok || got != want {
+				t.Errorf("Get(%q) = %q, %v, want %q", key, got, ok, want)
+			}
+		}
+	}
+	if got := d.Size(); got != writers*keys {
+		t.Fatalf("Size = %d, want %d", got, writers*keys)
+	}
+}
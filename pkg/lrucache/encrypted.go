@@ -0,0 +1,89 @@
+package lrucache
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+)
+
+// EncryptedCache is an LRU cache that keeps its values encrypted with
+// AES-256-GCM, for sensitive data such as sessions or tokens. Each value is
+// sealed with its own random nonce, prepended to the ciphertext, and bound to
+// its key as additional data so values cannot be swapped between keys.
+// Keys are stored in plaintext.
+type EncryptedCache struct {
+	cache *LRUCache
+	aead  cipher.AEAD
+}
+
+// NewEncryptedCache creates a new EncryptedCache Instance with the specified
+// capacity, encrypting values with the 256-bit key.
+func NewEncryptedCache(capacity int, key [32]byte) (*EncryptedCache, error) {
+	cache, err := NewLRUCache(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedCache{cache: cache, aead: aead}, nil
+}
+
+// Get retrieves and decrypts the value for a given key.
+// An entry that fails to decrypt is evicted and reported as missing, unless
+// a concurrent Put has already replaced it.
+func (c *EncryptedCache) Get(key string) (string, bool) {
+	sealed, ok := c.cache.Get(key)
+	if !ok {
+		return "", false
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		c.cache.CompareAndDelete(key, sealed)
+		return "", false
+	}
+	value, err := c.aead.Open(nil, []byte(sealed[:nonceSize]), []byte(sealed[nonceSize:]), []byte(key))
+	if err != nil {
+		c.cache.CompareAndDelete(key, sealed)
+		return "", false
+	}
+	return string(value), true
+}
+
+// Put encrypts the value and stores it under key.
+func (c *EncryptedCache) Put(key string, value string) error {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(value)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), []byte(key))
+	return c.cache.PutE(key, string(sealed))
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *EncryptedCache) Delete(key string) bool {
+	return c.cache.Delete(key)
+}
+
+// Has checks if the cache contains a specific key.
+func (c *EncryptedCache) Has(key string) bool {
+	return c.cache.Has(key)
+}
+
+// Clear removes all items from the cache.
+func (c *EncryptedCache) Clear() {
+	c.cache.Clear()
+}
+
+// Size returns the current number of items in the cache.
+func (c *EncryptedCache) Size() int {
+	return c.cache.Size()
+}
@@ -0,0 +1,58 @@
+package lrucache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncryptedCache(t *testing.T) {
+	c, err := NewEncryptedCache(4, [32]byte{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("session", "secret-token"); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := c.Get("session"); !ok || value != "secret-token" {
+		t.Fatalf("Get = %q, %v, want secret-token", value, ok)
+	}
+	if stored, _ := c.cache.Get("session"); strings.Contains(stored, "secret-token") {
+		t.Fatal("value stored in plaintext")
+	}
+
+	// Values are bound to their key.
+	stored, _ := c.cache.Get("session")
+	c.cache.Put("other", stored)
+	if _, ok := c.Get("other"); ok || c.Has("other") {
+		t.Fatal("a value moved to another key decrypted and was kept")
+	}
+
+	// Tampered and truncated values are evicted.
+	for _, bad := range []string{stored[:len(stored)-1] + "x", "short"} {
+		c.cache.Put("session", bad)
+		if _, ok := c.Get("session"); ok || c.Has("session") {
+			t.Fatalf("value %q decrypted or was kept", bad)
+		}
+	}
+}
+
+func TestCompareAndDelete(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("k", "bad")
+	bad, _ := c.Get("k")
+
+	// A concurrent Put replaced the value the caller found bad.
+	c.Put("k", "fresh")
+	if c.CompareAndDelete("k", bad) {
+		t.Fatal("CompareAndDelete removed a value that had been replaced")
+	}
+	if value, _ := c.Get("k"); value != "fresh" {
+		t.Fatalf("Get = %q, want fresh", value)
+	}
+	if !c.CompareAndDelete("k", "fresh") || c.Has("k") {
+		t.Fatal("CompareAndDelete kept a matching value")
+	}
+	if c.CompareAndDelete("missing", "") {
+		t.Fatal("CompareAndDelete removed a missing key")
+	}
+}
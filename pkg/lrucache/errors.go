@@ -0,0 +1,25 @@
+package lrucache
+
+import "errors"
+
+// Errors returned by the package, for use with errors.Is. See also
+// ErrCacheFull.
+var (
+	// ErrInvalidCapacity is returned by the constructors and Resize for a capacity below 1.
+	ErrInvalidCapacity = errors.New("invalid capacity: must be greater than 0")
+	// ErrKeyTooLong is returned when a key exceeds WithMaxKeyBytes.
+	ErrKeyTooLong = errors.New("key too long: exceeds the maximum key length")
+	// ErrOversizedValue is returned when an entry exceeds WithMaxBytes on its own.
+	ErrOversizedValue = errors.New("value too large: exceeds the cache byte limit")
+	// ErrBatchTooLarge is returned by PutAllOrNothing for a batch that cannot
+	// fit in the cache as a whole.
+	ErrBatchTooLarge = errors.New("batch too large: exceeds the cache capacity or byte limit")
+	// ErrNoLoader is returned by GetOrLoad on a cache built without WithLoader.
+	ErrNoLoader = errors.New("no loader configured")
+	// ErrLoaderPanic is wrapped by the error GetOrLoad returns when the loader panics.
+	ErrLoaderPanic = errors.New("loader panicked")
+	// ErrKeyNotFound is returned by GetE for a key that is not in the cache.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrBreakerOpen is ErrLoaderBreakerOpen, under the shorter name.
+	ErrBreakerOpen = ErrLoaderBreakerOpen
+)
@@ -0,0 +1,63 @@
+package lrucache
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInvalidCapacity(t *testing.T) {
+	constructors := map[string]func(capacity int) error{
+		"NewLRUCache": func(n int) error { _, err := NewLRUCache(n); return err },
+		"NewLRUCacheWithOptions": func(n int) error {
+			_, err := NewLRUCacheWithOptions(n, WithTTL(0))
+			return err
+		},
+		"NewMRUCache":        func(n int) error { _, err := NewMRUCache(n); return err },
+		"NewLFUCache":        func(n int) error { _, err := NewLFUCache(n); return err },
+		"NewPriorityCache":   func(n int) error { _, err := NewPriorityCache(n); return err },
+		"NewBytesLRUCache":   func(n int) error { _, err := NewBytesLRUCache(n); return err },
+		"NewUnsafeLRUCache":  func(n int) error { _, err := NewUnsafeLRUCache(n); return err },
+		"NewBoundedStack":    func(n int) error { _, err := NewBoundedStack(n); return err },
+		"NewJSONCache":       func(n int) error { _, err := NewJSONCache[int](n); return err },
+		"NewSignedCache":     func(n int) error { _, err := NewSignedCache(n, []byte("secret")); return err },
+		"NewEncryptedCache":  func(n int) error { _, err := NewEncryptedCache(n, [32]byte{}); return err },
+		"SetAssociativeWays": func(n int) error { _, err := NewSetAssociativeCache(1, n); return err },
+		"Resize": func(n int) error {
+			c, _ := NewLRUCache(1)
+			return c.Resize(n)
+		},
+	}
+	for name, construct := range constructors {
+		for _, capacity := range []int{0, -1} {
+			if err := construct(capacity); !errors.Is(err, ErrInvalidCapacity) {
+				t.Errorf("%s(%d) error = %v, want ErrInvalidCapacity", name, capacity, err)
+			}
+		}
+		if err := construct(1); err != nil {
+			t.Errorf("%s(1) error = %v", name, err)
+		}
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithMaxBytes(8), WithMaxKeyBytes(4))
+	if err := c.PutE("k", "too large a value"); !errors.Is(err, ErrOversizedValue) {
+		t.Fatalf("oversized PutE error = %v, want ErrOversizedValue", err)
+	}
+	if err := c.PutE("long key", "v"); !errors.Is(err, ErrKeyTooLong) {
+		t.Fatalf("long key PutE error = %v, want ErrKeyTooLong", err)
+	}
+	if _, err := c.GetOrLoad("k"); !errors.Is(err, ErrNoLoader) {
+		t.Fatalf("GetOrLoad without a loader error = %v, want ErrNoLoader", err)
+	}
+	if _, err := c.GetE("k"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("GetE of a missing key error = %v, want ErrKeyNotFound", err)
+	}
+	c.Put("k", "v")
+	if value, err := c.GetE("k"); err != nil || value != "v" {
+		t.Fatalf("GetE = %q, %v, want v", value, err)
+	}
+	if !errors.Is(ErrLoaderBreakerOpen, ErrBreakerOpen) {
+		t.Fatal("ErrBreakerOpen is not ErrLoaderBreakerOpen")
+	}
+}
@@ -0,0 +1,55 @@
+package lrucache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ETag returns a strong entity tag for the value cached under key: the
+// SHA-256 hex digest of the value in double quotes, as RFC 7232 requires.
+// The entry is not promoted.
+func (c *LRUCache) ETag(key string) (etag string, ok bool) {
+	c.mutex.RLock()
+	node, ok := c.Cache[c.normalizeKey(key)]
+	if !ok || !c.live(node) {
+		c.mutex.RUnlock()
+		return "", false
+	}
+	value := node.Value
+	c.mutex.RUnlock()
+
+	return computeETag(value), true
+}
+
+// GetIfNoneMatch is a Get for conditional HTTP requests. etag is the value of an
+// If-None-Match header, a list of entity tags or "*". When it matches the
+// cached value it returns "", true, true so the caller can answer 304 Not
+// Modified; otherwise it returns the value like Get.
+func (c *LRUCache) GetIfNoneMatch(key, etag string) (value string, notModified bool, ok bool) {
+	value, ok = c.Get(key)
+	if !ok {
+		return "", false, false
+	}
+	if etag != "" && etagMatches(etag, computeETag(value)) {
+		return "", true, true
+	}
+	return value, false, true
+}
+
+// computeETag returns the quoted SHA-256 hex digest of value.
+func computeETag(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// etagMatches applies the weak comparison If-None-Match uses to a header value.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
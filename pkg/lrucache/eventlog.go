@@ -0,0 +1,83 @@
+package lrucache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// EventRecord is a single operation kept by the event log.
+type EventRecord struct {
+	Op     string    `json:"op"` // get, put, delete or evict
+	Key    string    `json:"key"`
+	Result string    `json:"result"` // hit, miss, stored, rejected, deleted, absent or the eviction reason
+	Time   time.Time `json:"ts"`
+}
+
+// eventLog is a fixed-size ring of records. Writers claim a slot with an
+// atomic cursor, so recording never takes the cache lock.
+type eventLog struct {
+	slots  []atomic.Pointer[EventRecord]
+	cursor atomic.Uint64
+}
+
+// WithEventLog keeps the last n cache operations in memory for post-incident
+// debugging, queryable through EventLog and EventLogForKey.
+// Disabled by default, in which case recording costs a single nil check.
+func WithEventLog(n int) Option {
+	return func(c *LRUCache) {
+		if n > 0 {
+			c.events = &eventLog{slots: make([]atomic.Pointer[EventRecord], n)}
+		}
+	}
+}
+
+// recordEvent appends an operation to the event log, if enabled.
+func (c *LRUCache) recordEvent(op, key, result string) {
+	if c.events == nil {
+		return
+	}
+
+	i := c.events.cursor.Add(1) - 1
+	c.events.slots[i%uint64(len(c.events.slots))].Store(&EventRecord{
+		Op:     op,
+		Key:    key,
+		Result: result,
+		Time:   c.now(),
+	})
+}
+
+// EventLog returns the recorded operations, oldest first.
+// It returns nil when the event log is disabled.
+func (c *LRUCache) EventLog() []EventRecord {
+	if c.events == nil {
+		return nil
+	}
+
+	n := uint64(len(c.events.slots))
+	end := c.events.cursor.Load()
+	start := uint64(0)
+	if end > n {
+		start = end - n
+	}
+
+	records := make([]EventRecord, 0, end-start)
+	for i := start; i < end; i++ {
+		if record := c.events.slots[i%n].Load(); record != nil {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
+
+// EventLogForKey returns the recorded operations on key, oldest first.
+func (c *LRUCache) EventLogForKey(key string) []EventRecord {
+	key = c.normalizeKey(key)
+
+	var records []EventRecord
+	for _, record := range c.EventLog() {
+		if record.Key == key {
+			records = append(records, record)
+		}
+	}
+	return records
+}
@@ -0,0 +1,208 @@
+package lrucache
+
+import (
+	"log/slog"
+	"time"
+)
+
+// EvictionReason describes why an entry left the cache.
+type EvictionReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room for a new one.
+	ReasonCapacity EvictionReason = iota
+	// ReasonDeleted means the entry was removed by Delete.
+	ReasonDeleted
+	// ReasonDrained means the entry was handed out by Drain.
+	ReasonDrained
+	// ReasonExpired means the entry was considered too old.
+	ReasonExpired
+	// ReasonCleared means the entry was removed by Clear.
+	ReasonCleared
+)
+
+// String returns a readable name for the reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonDeleted:
+		return "deleted"
+	case ReasonDrained:
+		return "drained"
+	case ReasonExpired:
+		return "expired"
+	case ReasonCleared:
+		return "cleared"
+	default:
+		return "unknown"
+	}
+}
+
+// eviction is a callback invocation queued while the write lock is held.
+type eviction struct {
+	key    string
+	value  string
+	reason EvictionReason
+	age    time.Duration // time since the last access
+}
+
+// WithOnEvict registers fn to be called for every entry that leaves the cache.
+// Callbacks run after the cache lock has been released, in eviction order,
+// so fn may safely call back into the cache.
+func WithOnEvict(fn func(key, value string, reason EvictionReason)) Option {
+	return func(c *LRUCache) {
+		c.onEvict = fn
+	}
+}
+
+// SetOnEvict replaces the eviction callback at runtime, like WithOnEvict.
+// A nil fn removes it.
+func (c *LRUCache) SetOnEvict(fn func(key, value string, reason EvictionReason)) {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	c.onEvict = fn
+}
+
+// WithNotifyOnClear makes Clear fire the eviction callbacks for every entry with
+// ReasonCleared, least recently used first, after the cache has been emptied
+// and the lock released. Off by default since large caches make Clear costly.
+func WithNotifyOnClear(enabled bool) Option {
+	return func(c *LRUCache) {
+		c.notifyOnClear = enabled
+	}
+}
+
+// WithSecureErase overwrites the Value of a Node when it is evicted, deleted or
+// cleared, and eviction callbacks receive an empty value in this mode.
+//
+// Go strings are immutable, so for the string-valued LRUCache this only drops the
+// cache's reference to the value: the bytes stay in memory until the garbage
+// collector reclaims them and are not zeroed. For real zeroing store the values
+// in a BytesLRUCache built WithBytesSecureErase.
+func WithSecureErase(enabled bool) Option {
+	return func(c *LRUCache) {
+		c.secureErase = enabled
+	}
+}
+
+// WithSecureEraseKeys extends WithSecureErase to the Node's Key as well.
+// Callbacks still receive the key.
+func WithSecureEraseKeys(enabled bool) Option {
+	return func(c *LRUCache) {
+		c.secureErase = c.secureErase || enabled
+		c.eraseKeys = enabled
+	}
+}
+
+// EvictOlderThan removes every entry that was last inserted or accessed
+// more than age ago and returns the number of entries removed.
+func (c *LRUCache) EvictOlderThan(age time.Duration) int {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	start := time.Now()
+	cutoff := c.now().Add(-age)
+	removed := 0
+	for node := c.Tail; node != nil; {
+		prev := node.Prev
+		if node.accessedAt.Before(cutoff) {
+			c.removeEntry(node, ReasonExpired)
+			removed++
+		}
+		node = prev
+	}
+
+	c.queueSweep("evict_older_than", slog.LevelInfo, removed, time.Since(start))
+	return removed
+}
+
+// removeEntry unlinks a node, drops it from the map and queues the eviction
+// callback. The caller must hold the write lock.
+func (c *LRUCache) removeEntry(node *Node, reason EvictionReason) {
+	c.removeNode(node)
+	delete(c.Cache, node.Key)
+	if c.index != nil {
+		c.index.remove(c.index.hash(node.Key), node)
+	}
+	if c.reserve != nil {
+		c.reserve.add(node.Key, -1)
+	}
+	c.unscheduleExpiry(node)
+	c.bytes -= node.cost
+	c.release(node.Value)
+	if reason == ReasonDeleted {
+		c.auditOp("delete", node.Key, node.Value)
+		c.replicateDelete(node.Key)
+	} else {
+		c.auditOp("evict", node.Key, node.Value)
+		c.recordEvent("evict", node.Key, reason.String())
+		c.rememberEviction(node.Key, reason)
+		if reason == ReasonCapacity && c.fullSignal != nil {
+			c.signalFull()
+		}
+		if !node.read && !node.deleted && (reason == ReasonCapacity || reason == ReasonExpired) {
+			c.evictedUnread++
+		}
+	}
+	c.notify(node, reason)
+	c.erase(node)
+	c.signalFreed()
+}
+
+// notify queues the eviction callback for node, if one is registered.
+func (c *LRUCache) notify(node *Node, reason EvictionReason) {
+	if c.onEvict == nil && c.logger == nil && c.victimCache == nil {
+		return
+	}
+
+	value := node.Value
+	if c.secureErase {
+		value = ""
+	}
+	c.pending = append(c.pending, eviction{
+		key:    node.Key,
+		value:  value,
+		reason: reason,
+		age:    c.now().Sub(node.accessedAt),
+	})
+}
+
+// erase clears a removed node when secure erase is enabled.
+func (c *LRUCache) erase(node *Node) {
+	if !c.secureErase {
+		return
+	}
+
+	node.Value = ""
+	if c.eraseKeys {
+		node.Key = ""
+	}
+	node.Prev = nil
+	node.Next = nil
+}
+
+// unlock releases the write lock and then runs the eviction callbacks
+// and log records collected while it was held.
+func (c *LRUCache) unlock() {
+	pending := c.pending
+	c.pending = nil
+	sweeps := c.sweeps
+	c.sweeps = nil
+	onEvict := c.onEvict
+	c.mutex.Unlock()
+
+	for _, s := range sweeps {
+		c.logSweep(s)
+	}
+	for _, e := range pending {
+		c.logEviction(e)
+		if onEvict != nil {
+			onEvict(e.key, e.value, e.reason)
+		}
+		if c.victimCache != nil && e.reason == ReasonCapacity && !c.secureErase {
+			c.victimCache.Put(e.key, e.value)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package lrucache
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects eviction callbacks.
+type recorder struct {
+	mutex  sync.Mutex
+	events []eviction
+}
+
+func (r *recorder) onEvict(key, value string, reason EvictionReason) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, eviction{key: key, value: value, reason: reason})
+}
+
+func (r *recorder) got() []eviction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return slices.Clone(r.events)
+}
+
+func TestSecureErase(t *testing.T) {
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(1, WithSecureErase(true), WithSecureEraseKeys(true), WithOnEvict(r.onEvict))
+	c.Put("a", "token-a")
+	evicted := c.Cache["a"]
+	c.Put("b", "token-b")
+	deleted := c.Cache["b"]
+	c.Delete("b")
+
+	for _, node := range []*Node{evicted, deleted} {
+		if node.Value != "" || node.Key != "" || node.Prev != nil || node.Next != nil {
+			t.Fatalf("removed node was not erased: %+v", *node)
+		}
+	}
+	want := []eviction{{key: "a", reason: ReasonCapacity}, {key: "b", reason: ReasonDeleted}}
+	if got := r.got(); !slices.Equal(got, want) {
+		t.Fatalf("callbacks = %+v, want %+v with empty values", got, want)
+	}
+}
+
+func TestBytesSecureErase(t *testing.T) {
+	c, _ := NewBytesLRUCache(1, WithBytesSecureErase(true))
+
+	evicted := []byte("token-a")
+	c.Put("a", evicted)
+	got, _ := c.Get("a")
+	got[0] = 'X' // Get hands out a copy in this mode
+	c.Put("b", []byte("token-b"))
+	if string(evicted) != "\x00\x00\x00\x00\x00\x00\x00" {
+		t.Fatalf("evicted value not zeroed: %q", evicted)
+	}
+
+	overwritten := []byte("token-b2")
+	c.Put("b", overwritten)
+	c.Put("b", []byte("token-b3"))
+	if string(overwritten) != "\x00\x00\x00\x00\x00\x00\x00\x00" {
+		t.Fatalf("overwritten value not zeroed: %q", overwritten)
+	}
+
+	deleted := []byte("token-c")
+	c.Put("c", deleted)
+	c.Delete("c")
+	cleared := []byte("token-d")
+	c.Put("d", cleared)
+	c.Clear()
+	for _, value := range [][]byte{deleted, cleared} {
+		for _, b := range value {
+			if b != 0 {
+				t.Fatalf("deleted or cleared value not zeroed: %q", value)
+			}
+		}
+	}
+}
+
+func TestBytesKeepsValuesWithoutSecureErase(t *testing.T) {
+	c, _ := NewBytesLRUCache(1)
+	value := []byte("payload")
+	c.Put("a", value)
+	c.Put("b", []byte("other"))
+	if string(value) != "payload" {
+		t.Fatalf("value modified on eviction without secure erase: %q", value)
+	}
+}
+
+func TestEvictOlderThan(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(10, WithClock(func() time.Time { return now }), WithOnEvict(r.onEvict))
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.Put(key, "v")
+	}
+	now = now.Add(time.Hour)
+	c.Get("b")
+	c.Put("d", "v2")
+
+	if removed := c.EvictOlderThan(30 * time.Minute); removed != 2 {
+		t.Fatalf("EvictOlderThan removed %d entries, want 2", removed)
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"d", "b"}) {
+		t.Fatalf("keys = %v, want [d b]", got)
+	}
+	want := []eviction{{key: "a", value: "v", reason: ReasonExpired}, {key: "c", value: "v", reason: ReasonExpired}}
+	if got := r.got(); !slices.Equal(got, want) {
+		t.Fatalf("callbacks = %+v, want %+v", got, want)
+	}
+	checkIntegrity(t, c)
+
+	if removed := c.EvictOlderThan(30 * time.Minute); removed != 0 {
+		t.Fatalf("second EvictOlderThan removed %d entries, want 0", removed)
+	}
+}
+
+// hotHitsWithHugeEntries reads a small hot set every round, then writes one
+// huge entry and a few medium cold ones behind it, and reports the hot hits.
+func hotHitsWithHugeEntries(lookback int) int {
+	c, _ := NewLRUCacheWithOptions(100, WithMaxBytes(1000), WithEvictionLookback(lookback))
+	hot := make([]string, 10)
+	for i := range hot {
+		hot[i] = "h" + strconv.Itoa(i)
+		c.Put(hot[i], "0123456789")
+	}
+
+	hits := 0
+	for round := range 50 {
+		for _, key := range hot {
+			if _, ok := c.Get(key); ok {
+				hits++
+			} else {
+				c.Put(key, "0123456789")
+			}
+		}
+		c.Put("big"+strconv.Itoa(round), strings.Repeat("b", 600))
+		for i := range 4 {
+			c.Put("cold"+strconv.Itoa(round*4+i), strings.Repeat("c", 90))
+		}
+	}
+	return hits
+}
+
+func TestEvictionLookbackKeepsSmallHotEntries(t *testing.T) {
+	const lookups = 50 * 10
+	if hits := hotHitsWithHugeEntries(16); hits != lookups {
+		t.Fatalf("lookback 16: %d of %d hot lookups hit, want all", hits, lookups)
+	}
+	if hits := hotHitsWithHugeEntries(1); hits > lookups/2 {
+		t.Fatalf("pure LRU: %d of %d hot lookups hit, want the huge entries to push the hot set out", hits, lookups)
+	}
+}
+
+func TestNotifyOnClear(t *testing.T) {
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(4, WithNotifyOnClear(true))
+	c.SetOnEvict(func(key, value string, reason EvictionReason) {
+		c.Size() // the lock is released before callbacks run
+		r.onEvict(key, value, reason)
+	})
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	c.Get("a")
+
+	c.Clear()
+	if c.Size() != 0 {
+		t.Fatalf("Size() = %d after Clear, want 0", c.Size())
+	}
+	want := []eviction{
+		{key: "b", value: "2", reason: ReasonCleared},
+		{key: "c", value: "3", reason: ReasonCleared},
+		{key: "a", value: "1", reason: ReasonCleared},
+	}
+	if got := r.got(); !slices.Equal(got, want) {
+		t.Fatalf("callbacks = %+v, want %+v", got, want)
+	}
+
+	c, _ = NewLRUCacheWithOptions(4, WithOnEvict(r.onEvict))
+	c.Put("a", "1")
+	c.Clear()
+	if got := r.got(); len(got) != len(want) {
+		t.Fatalf("Clear fired callbacks without WithNotifyOnClear: %+v", got[len(want):])
+	}
+}
+
+func TestExpireMulti(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(10, WithOnEvict(r.onEvict), WithClock(func() time.Time { return now }))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	c.PutWithTTL("old", "4", time.Minute)
+	now = now.Add(time.Hour)
+
+	got := c.ExpireMulti([]string{"c", "missing", "a", "old", "a"})
+	if want := []string{"c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("ExpireMulti = %v, want %v", got, want)
+	}
+	if c.Has("a") || c.Has("c") || !c.Has("b") {
+		t.Fatalf("keys = %v, want only b left", c.Keys())
+	}
+	want := []eviction{{key: "c", value: "3", reason: ReasonExpired}, {key: "a", value: "1", reason: ReasonExpired}}
+	if got := r.got(); !slices.Equal(got, want) {
+		t.Fatalf("callbacks = %+v, want %+v", got, want)
+	}
+	if got := c.ExpireMulti(nil); got != nil {
+		t.Fatalf("ExpireMulti(nil) = %v, want nil", got)
+	}
+}
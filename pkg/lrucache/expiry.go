@@ -0,0 +1,157 @@
+package lrucache
+
+import (
+	"container/heap"
+	"log/slog"
+	"time"
+)
+
+// reaperHeartbeat is the longest the reaper sleeps between sweeps, even with
+// nothing due to expire, so Healthcheck can tell an idle reaper from a dead one.
+const reaperHeartbeat = time.Minute
+
+// reaper removes expired entries in the background, see WithExpirationReaper.
+type reaper struct {
+	heap      expiryHeap    // entries with a TTL, soonest expiry first
+	wake      chan struct{} // signalled when the soonest expiry moves earlier
+	stop      chan struct{}
+	done      chan struct{}
+	lastSweep time.Time // wall clock time of the last sweep, for Healthcheck
+}
+
+// WithExpirationReaper removes expired entries in the background instead of
+// waiting for them to be looked up, so Size and the byte count stay accurate.
+// Entries with a TTL are kept in a min-heap by expiration time: the reaper
+// sleeps until the soonest one expires and only pops the entries that have,
+// in O(log n) each, reporting them to the eviction callbacks as ReasonExpired.
+// Expired entries are then rarely around for WithServeStaleOnError to fall
+// back to. Call Close to stop the reaper goroutine.
+func WithExpirationReaper() Option {
+	return func(c *LRUCache) {
+		r := &reaper{
+			wake:      make(chan struct{}, 1),
+			stop:      make(chan struct{}),
+			done:      make(chan struct{}),
+			lastSweep: time.Now(),
+		}
+		c.reaper = r
+		go c.reap(r)
+	}
+}
+
+// reap removes entries as they expire until the reaper is stopped.
+func (c *LRUCache) reap(r *reaper) {
+	defer close(r.done)
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		c.mutex.Lock()
+		start := time.Now()
+		r.lastSweep = start
+		purged := 0
+		for len(r.heap) > 0 && c.expired(r.heap[0]) {
+			c.removeEntry(r.heap[0], ReasonExpired)
+			purged++
+		}
+		if purged > 0 {
+			c.queueSweep("reaper", slog.LevelDebug, purged, time.Since(start))
+		}
+		wait := reaperHeartbeat
+		if len(r.heap) > 0 {
+			wait = min(wait, r.heap[0].expiresAt.Sub(c.now()))
+		}
+		c.unlock()
+
+		timer.Reset(max(wait, 0))
+		select {
+		case <-r.stop:
+			return
+		case <-r.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// scheduleExpiry updates the position of a node in the expiry heap after its
+// expiration time was set. The caller must hold the write lock.
+func (c *LRUCache) scheduleExpiry(node *Node) {
+	r := c.reaper
+	if r == nil {
+		return
+	}
+
+	switch {
+	case node.expiresAt.IsZero():
+		c.unscheduleExpiry(node)
+		return
+	case node.expiryIndex > 0:
+		heap.Fix(&r.heap, node.expiryIndex-1)
+	default:
+		heap.Push(&r.heap, node)
+	}
+	if r.heap[0] == node {
+		select {
+		case r.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// unscheduleExpiry drops a node from the expiry heap.
+// The caller must hold the write lock.
+func (c *LRUCache) unscheduleExpiry(node *Node) {
+	if c.reaper != nil && node.expiryIndex > 0 {
+		heap.Remove(&c.reaper.heap, node.expiryIndex-1)
+	}
+}
+
+// stopReaper stops the reaper goroutine, if any, and waits for it to exit.
+func (c *LRUCache) stopReaper() {
+	c.mutex.Lock()
+	r := c.reaper
+	c.reaper = nil
+	if r != nil {
+		for _, node := range r.heap {
+			node.expiryIndex = 0
+		}
+		// A sweep that runs before the reaper sees stop must find nothing
+		// to remove, since removeEntry no longer pops the heap
+		r.heap = nil
+	}
+	c.mutex.Unlock()
+
+	if r != nil {
+		close(r.stop)
+		<-r.done
+	}
+}
+
+// expiryHeap is a min-heap of nodes by expiration time. Each node records its
+// position plus one in expiryIndex, zero while it is not in the heap.
+type expiryHeap []*Node
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].expiryIndex = i + 1
+	h[j].expiryIndex = j + 1
+}
+
+func (h *expiryHeap) Push(x any) {
+	node := x.(*Node)
+	node.expiryIndex = len(*h) + 1
+	*h = append(*h, node)
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	node := old[len(old)-1]
+	old[len(old)-1] = nil
+	node.expiryIndex = 0
+	*h = old[:len(old)-1]
+	return node
+}
@@ -0,0 +1,80 @@
+package lrucache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReaperRemovesInExpiryOrder(t *testing.T) {
+	var mutex sync.Mutex
+	removed := make(map[string]time.Time)
+	var order []string
+	c, _ := NewLRUCacheWithOptions(10, WithExpirationReaper(), WithOnEvict(func(key, value string, reason EvictionReason) {
+		if reason != ReasonExpired {
+			return // the explicit Delete below
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		removed[key] = time.Now()
+		order = append(order, key)
+	}))
+	defer c.Close()
+
+	start := time.Now()
+	ttls := map[string]time.Duration{
+		"40ms": 40 * time.Millisecond,
+		"10ms": 10 * time.Millisecond,
+		"70ms": 70 * time.Millisecond,
+		"25ms": 25 * time.Millisecond,
+	}
+	for key, ttl := range ttls {
+		c.PutWithTTL(key, "v", ttl)
+	}
+	c.PutWithTTL("deleted", "v", 5*time.Millisecond)
+	c.Delete("deleted")
+	c.PutWithTTL("moved", "v", 5*time.Millisecond)
+	c.PutWithTTL("moved", "v", 55*time.Millisecond) // a later expiry replaces the earlier one
+	ttls["moved"] = 55 * time.Millisecond
+	c.Put("forever", "v")
+
+	waitFor(t, "every entry to expire", func() bool { return c.Size() == 1 })
+	mutex.Lock()
+	defer mutex.Unlock()
+	want := []string{"10ms", "25ms", "40ms", "moved", "70ms"}
+	if len(order) != len(want) {
+		t.Fatalf("removed %v, want %v", order, want)
+	}
+	for i, key := range want {
+		if order[i] != key {
+			t.Fatalf("removed %v, want %v", order, want)
+		}
+		if at := removed[key].Sub(start); at < ttls[key] {
+			t.Fatalf("%s removed after %v, before its TTL of %v", key, at, ttls[key])
+		}
+	}
+	if !c.Has("forever") {
+		t.Fatal("the entry without a TTL was removed")
+	}
+}
+
+func TestReaperHeapFollowsResize(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithExpirationReaper())
+	defer c.Close()
+	for i := range 10 {
+		c.PutWithTTL(strconv.Itoa(i), "v", time.Hour)
+	}
+	c.Resize(3)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.reaper.heap) != 3 {
+		t.Fatalf("expiry heap holds %d entries after Resize(3), want 3", len(c.reaper.heap))
+	}
+	for i, node := range c.reaper.heap {
+		if c.Cache[node.Key] != node || node.expiryIndex != i+1 {
+			t.Fatalf("heap entry %d (%s) is stale", i, node.Key)
+		}
+	}
+}
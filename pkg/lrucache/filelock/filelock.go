@@ -0,0 +1,366 @@
+//go:build unix
+
+// Package filelock provides an LRU cache stored in a memory-mapped file, so that
+// several processes on the same host (e.g. fiber.Prefork workers) can share it.
+// Access is serialized between processes with advisory flock(2) locks.
+package filelock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+)
+
+// File layout: a fixed header followed by the entries, most recently used first.
+// Each entry is a key length and a value length (uint32) followed by the bytes.
+const (
+	headerSize      = 16
+	entryHeaderSize = 8
+)
+
+var magic = []byte("LRUF")
+
+var (
+	// ErrClosed is returned by operations on a FileLockCache after Close.
+	ErrClosed = errors.New("filelock: cache is closed")
+	// ErrCorrupt is returned by NewFileLockCache when the file header does not
+	// match the entries that follow it.
+	ErrCorrupt = errors.New("filelock: corrupt cache file")
+)
+
+// FileLockCache is an LRU cache backed by a shared memory-mapped file.
+// Reads take a shared lock and writes an exclusive one, so cooperating processes
+// see a consistent list. Get takes the exclusive lock too since it reorders entries.
+// Operations scan the file, so this suits small caches shared across processes
+// rather than large hot ones.
+type FileLockCache struct {
+	file     *os.File
+	data     []byte
+	capacity int
+	mutex    sync.Mutex // flock is per open file, so goroutines serialize here first
+	closed   bool       // set by Close, guarded by mutex
+}
+
+var _ lrucache.Cache = (*FileLockCache)(nil)
+
+// NewFileLockCache opens (or creates) the cache file at path, sized to hold at most
+// size bytes of entries and capacity entries. Processes sharing a file must use
+// the same size; an existing larger file keeps its size. An existing file whose
+// header does not describe the entries that follow it is rejected with
+// ErrCorrupt rather than mapped and trusted.
+func NewFileLockCache(path string, capacity int, size int) (*FileLockCache, error) {
+	if capacity <= 0 {
+		return nil, lrucache.ErrInvalidCapacity
+	}
+	if size <= headerSize {
+		return nil, errors.New("invalid size: too small to hold any entry")
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &FileLockCache{file: file, capacity: capacity}
+	err = c.withLock(syscall.LOCK_EX, func() error {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() < int64(size) {
+			if err := file.Truncate(int64(size)); err != nil {
+				return err
+			}
+		} else {
+			size = int(info.Size())
+		}
+
+		c.data, err = syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(c.data[:4], magic) {
+			copy(c.data, magic)
+			c.setCount(0)
+			c.setUsed(0)
+			return nil
+		}
+		return c.validate()
+	})
+	if err != nil {
+		if c.data != nil {
+			syscall.Munmap(c.data)
+		}
+		file.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close unmaps the file and closes it. The cache contents stay on disk.
+// Later calls fail with ErrClosed, or behave as on an empty cache for the
+// methods without an error result.
+func (c *FileLockCache) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+	c.closed = true
+	if err := syscall.Munmap(c.data); err != nil {
+		return err
+	}
+	c.data = nil
+	return c.file.Close()
+}
+
+// Get retrieves the value for a given key and moves it to the front of the file.
+func (c *FileLockCache) Get(key string) (string, bool) {
+	value, err := c.GetE(key)
+	return value, err == nil
+}
+
+// GetE is like Get but returns lrucache.ErrKeyNotFound on a miss, and the
+// error that made the lookup fail otherwise, such as ErrClosed.
+func (c *FileLockCache) GetE(key string) (string, error) {
+	var value string
+	err := c.withLock(syscall.LOCK_EX, func() error {
+		off, ok := c.find(key)
+		if !ok {
+			return lrucache.ErrKeyNotFound
+		}
+		value = c.valueAt(off)
+		c.moveToFront(off)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// Put adds a key-value pair, evicting the least recently used entries as needed.
+// Entries larger than the file are dropped; use PutE to see the error.
+func (c *FileLockCache) Put(key string, value string) {
+	_ = c.PutE(key, value)
+}
+
+// PutE is like Put but returns an error when the entry cannot fit in the file.
+func (c *FileLockCache) PutE(key string, value string) error {
+	size := entryHeaderSize + len(key) + len(value)
+	return c.withLock(syscall.LOCK_EX, func() error {
+		if size > len(c.data)-headerSize {
+			return errors.New("entry too large for the cache file")
+		}
+		if off, ok := c.find(key); ok {
+			c.remove(off)
+		}
+		for c.count() > 0 && (c.count() >= c.capacity || c.used()+size > len(c.data)-headerSize) {
+			c.remove(c.lastOffset())
+		}
+
+		// Shift every entry right and write the new one at the front
+		start := headerSize
+		copy(c.data[start+size:], c.data[start:start+c.used()])
+		binary.LittleEndian.PutUint32(c.data[start:], uint32(len(key)))
+		binary.LittleEndian.PutUint32(c.data[start+4:], uint32(len(value)))
+		copy(c.data[start+entryHeaderSize:], key)
+		copy(c.data[start+entryHeaderSize+len(key):], value)
+
+		c.setCount(c.count() + 1)
+		c.setUsed(c.used() + size)
+		return nil
+	})
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *FileLockCache) Delete(key string) bool {
+	deleted := false
+	_ = c.withLock(syscall.LOCK_EX, func() error {
+		if off, ok := c.find(key); ok {
+			c.remove(off)
+			deleted = true
+		}
+		return nil
+	})
+	return deleted
+}
+
+// Has checks if the cache contains a specific key.
+func (c *FileLockCache) Has(key string) bool {
+	found := false
+	_ = c.withLock(syscall.LOCK_SH, func() error {
+		_, found = c.find(key)
+		return nil
+	})
+	return found
+}
+
+// Clear removes all items from the cache.
+func (c *FileLockCache) Clear() {
+	_ = c.withLock(syscall.LOCK_EX, func() error {
+		c.setCount(0)
+		c.setUsed(0)
+		return nil
+	})
+}
+
+// Size returns the current number of items in the cache.
+func (c *FileLockCache) Size() int {
+	size := 0
+	_ = c.withLock(syscall.LOCK_SH, func() error {
+		size = c.count()
+		return nil
+	})
+	return size
+}
+
+// withLock runs fn while holding the in-process mutex and the file lock.
+// It returns ErrClosed without calling fn once the cache is closed.
+func (c *FileLockCache) withLock(how int, fn func() error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	fd := int(c.file.Fd())
+	if err := syscall.Flock(fd, how); err != nil {
+		return err
+	}
+	defer syscall.Flock(fd, syscall.LOCK_UN)
+
+	if err := c.checkMapping(); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// checkMapping makes sure the entries the shared header describes lie within
+// this process's mapping. Another process may have opened the file with a
+// larger size and filled it since, in which case the file is mapped again at
+// its new size. The caller must hold the file lock.
+func (c *FileLockCache) checkMapping() error {
+	if c.data == nil {
+		return nil // NewFileLockCache has not mapped the file yet
+	}
+	if c.used() >= 0 && headerSize+c.used() <= len(c.data) {
+		return nil
+	}
+
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() <= int64(len(c.data)) {
+		return ErrCorrupt
+	}
+	data, err := syscall.Mmap(int(c.file.Fd()), 0, int(info.Size()), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Munmap(c.data); err != nil {
+		syscall.Munmap(data)
+		return err
+	}
+	c.data = data
+	return c.validate()
+}
+
+func (c *FileLockCache) count() int     { return int(binary.LittleEndian.Uint32(c.data[4:])) }
+func (c *FileLockCache) setCount(n int) { binary.LittleEndian.PutUint32(c.data[4:], uint32(n)) }
+func (c *FileLockCache) used() int      { return int(binary.LittleEndian.Uint32(c.data[8:])) }
+func (c *FileLockCache) setUsed(n int)  { binary.LittleEndian.PutUint32(c.data[8:], uint32(n)) }
+
+// validate checks that the count and used fields of an existing header agree
+// with the entries in the mapped file, so a truncated or foreign file cannot
+// send the offset arithmetic out of bounds. It runs on open and after the
+// file is mapped again, see checkMapping.
+func (c *FileLockCache) validate() error {
+	end := headerSize + c.used()
+	if c.used() < 0 || end > len(c.data) || c.count() < 0 {
+		return ErrCorrupt
+	}
+	off := headerSize
+	for i := 0; i < c.count(); i++ {
+		if off+entryHeaderSize > end {
+			return ErrCorrupt
+		}
+		size := c.entrySize(off)
+		if size < entryHeaderSize || off+size > end {
+			return ErrCorrupt
+		}
+		off += size
+	}
+	if off != end {
+		return ErrCorrupt
+	}
+	return nil
+}
+
+// entrySize returns the encoded size of the entry at off.
+func (c *FileLockCache) entrySize(off int) int {
+	klen := int(binary.LittleEndian.Uint32(c.data[off:]))
+	vlen := int(binary.LittleEndian.Uint32(c.data[off+4:]))
+	return entryHeaderSize + klen + vlen
+}
+
+// find returns the offset of the entry holding key.
+func (c *FileLockCache) find(key string) (int, bool) {
+	off := headerSize
+	for i := 0; i < c.count(); i++ {
+		klen := int(binary.LittleEndian.Uint32(c.data[off:]))
+		if klen == len(key) && string(c.data[off+entryHeaderSize:off+entryHeaderSize+klen]) == key {
+			return off, true
+		}
+		off += c.entrySize(off)
+	}
+	return 0, false
+}
+
+// valueAt returns a copy of the value of the entry at off.
+func (c *FileLockCache) valueAt(off int) string {
+	klen := int(binary.LittleEndian.Uint32(c.data[off:]))
+	vlen := int(binary.LittleEndian.Uint32(c.data[off+4:]))
+	start := off + entryHeaderSize + klen
+	return string(c.data[start : start+vlen])
+}
+
+// lastOffset returns the offset of the least recently used entry.
+func (c *FileLockCache) lastOffset() int {
+	off := headerSize
+	for i := 1; i < c.count(); i++ {
+		off += c.entrySize(off)
+	}
+	return off
+}
+
+// remove drops the entry at off by shifting the following entries left.
+func (c *FileLockCache) remove(off int) {
+	size := c.entrySize(off)
+	end := headerSize + c.used()
+	copy(c.data[off:], c.data[off+size:end])
+	c.setCount(c.count() - 1)
+	c.setUsed(c.used() - size)
+}
+
+// moveToFront moves the entry at off to the front of the file.
+func (c *FileLockCache) moveToFront(off int) {
+	if off == headerSize {
+		return
+	}
+
+	size := c.entrySize(off)
+	entry := make([]byte, size)
+	copy(entry, c.data[off:off+size])
+	copy(c.data[headerSize+size:off+size], c.data[headerSize:off])
+	copy(c.data[headerSize:], entry)
+}
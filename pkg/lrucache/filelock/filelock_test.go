@@ -0,0 +1,169 @@
+//go:build unix
+
+package filelock
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache/lrucachetest"
+)
+
+func TestConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := NewFileLockCache(filepath.Join(t.TempDir(), "cache"), capacity, 64<<10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { c.Close() })
+		return c
+	})
+}
+
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	a, err := NewFileLockCache(path, 2, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewFileLockCache(path, 2, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	a.Put("x", "1")
+	a.Put("y", "2")
+	if value, ok := b.Get("x"); !ok || value != "1" {
+		t.Fatalf("Get(x) through the second handle = %q, %v, want 1", value, ok)
+	}
+	b.Put("z", "3")
+	if _, err := a.GetE("y"); !errors.Is(err, lrucache.ErrKeyNotFound) {
+		t.Fatalf("GetE(y) error = %v, want ErrKeyNotFound after eviction", err)
+	}
+	if a.Size() != 2 {
+		t.Fatalf("Size = %d, want 2", a.Size())
+	}
+}
+
+func TestRemapsGrownFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	small, err := NewFileLockCache(path, 100, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer small.Close()
+	large, err := NewFileLockCache(path, 100, 64<<10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer large.Close()
+
+	// Fill the file far past the first handle's 256-byte mapping.
+	value := strings.Repeat("v", 100)
+	for i := range 50 {
+		large.Put("key"+strconv.Itoa(i), value)
+	}
+	if got, ok := small.Get("key0"); !ok || got != value {
+		t.Fatalf("Get(key0) through the small handle = %q, %v, want the value", got, ok)
+	}
+	if small.Size() != 50 {
+		t.Fatalf("Size through the small handle = %d, want 50", small.Size())
+	}
+	small.Put("mine", "1")
+	if got, ok := large.Get("mine"); !ok || got != "1" {
+		t.Fatalf("Get(mine) through the large handle = %q, %v", got, ok)
+	}
+}
+
+func TestRejectsHeaderPastFileEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	a, err := NewFileLockCache(path, 4, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+	b, err := NewFileLockCache(path, 4, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	// A writer that claims more entry bytes than the file holds.
+	b.withLock(syscall.LOCK_EX, func() error {
+		b.setUsed(1 << 20)
+		return nil
+	})
+	if err := a.PutE("k", "v"); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("PutE error = %v, want ErrCorrupt", err)
+	}
+	if _, ok := a.Get("k"); ok || a.Has("k") {
+		t.Fatal("read through a corrupt header")
+	}
+}
+
+func TestRejectsCorruptHeader(t *testing.T) {
+	for name, corrupt := range map[string]func(data []byte){
+		"used past end":  func(data []byte) { binary.LittleEndian.PutUint32(data[8:], 1<<20) },
+		"count too high": func(data []byte) { binary.LittleEndian.PutUint32(data[4:], 5) },
+		"entry past used": func(data []byte) {
+			binary.LittleEndian.PutUint32(data[headerSize:], 1<<20)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cache")
+			c, err := NewFileLockCache(path, 4, 256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Put("key", "value")
+			c.Close()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			corrupt(data)
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewFileLockCache(path, 4, 256); !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("NewFileLockCache error = %v, want ErrCorrupt", err)
+			}
+		})
+	}
+}
+
+func TestClosed(t *testing.T) {
+	c, err := NewFileLockCache(filepath.Join(t.TempDir(), "cache"), 4, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Put("key", "value")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Close(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("second Close error = %v, want ErrClosed", err)
+	}
+	if err := c.PutE("key", "value"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("PutE error = %v, want ErrClosed", err)
+	}
+	if _, err := c.GetE("key"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("GetE error = %v, want ErrClosed", err)
+	}
+	c.Put("key", "value")
+	c.Clear()
+	if _, ok := c.Get("key"); ok || c.Has("key") || c.Delete("key") || c.Size() != 0 {
+		t.Fatal("closed cache still reports entries")
+	}
+}
@@ -0,0 +1,189 @@
+package lrucache
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FSCache is an LRU cache of file contents that implements fs.FS, so it can be
+// handed to anything accepting one, such as html/template.ParseFS or
+// http.FS. Files are added with Store under slash-separated paths as accepted
+// by fs.ValidPath; directories exist implicitly as the parents of stored files.
+// Opening a file counts as a use, listing a directory does not. Listing walks
+// every cached file, so keep directory reads off hot paths.
+type FSCache struct {
+	cache *LRUCache
+}
+
+var (
+	_ fs.FS        = (*FSCache)(nil)
+	_ fs.ReadDirFS = (*FSCache)(nil)
+)
+
+// modTimeMeta is the metadata key holding a file's modification time.
+const modTimeMeta = "mtime"
+
+// NewFSCache creates a new FSCache Instance holding up to capacity files.
+// opts configure the underlying LRUCache, e.g. WithMaxBytes or WithTTL.
+func NewFSCache(capacity int, opts ...Option) (*FSCache, error) {
+	cache, err := NewLRUCacheWithOptions(capacity, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &FSCache{cache: cache}, nil
+}
+
+// Store caches content as the file name, replacing any previous version.
+// content is copied, so the caller may reuse it.
+func (c *FSCache) Store(name string, content []byte, modTime time.Time) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "store", Path: name, Err: fs.ErrInvalid}
+	}
+	meta := map[string]string{modTimeMeta: strconv.FormatInt(modTime.UnixNano(), 10)}
+	return c.cache.PutWithMeta(name, string(content), meta)
+}
+
+// Remove drops the file name from the cache.
+// Returns true if it was present.
+func (c *FSCache) Remove(name string) bool {
+	return c.cache.Delete(name)
+}
+
+// Open opens the named file or directory for reading. Files are read from
+// the cached content without copying it.
+func (c *FSCache) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if node, ok := c.cache.lookup(c.cache.normalizeKey(name)); ok {
+		return &fsFile{Reader: strings.NewReader(node.Value), info: fileInfoOf(node.entry())}, nil
+	}
+	entries, ok := c.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &fsDir{info: fsFileInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+// ReadDir lists the named directory, sorted by file name.
+func (c *FSCache) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+
+	entries, ok := c.readDir(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return entries, nil
+}
+
+// readDir lists the files and subdirectories directly under dir, and reports
+// whether dir exists, which the root always does.
+func (c *FSCache) readDir(dir string) ([]fs.DirEntry, bool) {
+	prefix := dir + "/"
+	if dir == "." {
+		prefix = ""
+	}
+
+	c.cache.mutex.RLock()
+	files := c.cache.entries()
+	c.cache.mutex.RUnlock()
+
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for _, file := range files {
+		rest, ok := strings.CutPrefix(file.Key, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		child, _, isDir := strings.Cut(rest, "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		if isDir {
+			entries = append(entries, fs.FileInfoToDirEntry(fsFileInfo{name: child, dir: true}))
+		} else {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfoOf(file)))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, len(entries) > 0 || dir == "."
+}
+
+// fileInfoOf describes a cached file.
+func fileInfoOf(entry Entry) fsFileInfo {
+	nanos, _ := strconv.ParseInt(entry.Meta[modTimeMeta], 10, 64)
+	return fsFileInfo{
+		name:    path.Base(entry.Key),
+		size:    int64(len(entry.Value)),
+		modTime: time.Unix(0, nanos),
+	}
+}
+
+// fsFileInfo implements fs.FileInfo for cached files and implicit directories.
+type fsFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (i fsFileInfo) Name() string       { return i.name }
+func (i fsFileInfo) Size() int64        { return i.size }
+func (i fsFileInfo) ModTime() time.Time { return i.modTime }
+func (i fsFileInfo) IsDir() bool        { return i.dir }
+func (i fsFileInfo) Sys() any           { return nil }
+
+func (i fsFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// fsFile is an open cached file. The embedded reader also provides Seek,
+// ReadAt and WriteTo, so http.FileServer can serve ranges from it.
+type fsFile struct {
+	*strings.Reader
+	info fsFileInfo
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *fsFile) Close() error               { return nil }
+
+// fsDir is an open directory, listed when it was opened.
+type fsDir struct {
+	info    fsFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *fsDir) Close() error               { return nil }
+
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
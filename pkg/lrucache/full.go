@@ -0,0 +1,174 @@
+package lrucache
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCacheFull is returned by PutE and PutWithTTL when a cache built
+// WithOnFull(ErrorPolicy) is full, or when a BlockPolicy wait times out.
+var ErrCacheFull = errors.New("cache full")
+
+// FullPolicy decides what a Put of a new key does when the cache is full.
+type FullPolicy struct {
+	kind    fullKind
+	timeout time.Duration
+}
+
+type fullKind int
+
+const (
+	fullEvict fullKind = iota
+	fullError
+	fullBlock
+)
+
+var (
+	// EvictPolicy evicts the least recently used entries to make room. This is the default.
+	EvictPolicy = FullPolicy{kind: fullEvict}
+	// ErrorPolicy rejects the new entry with ErrCacheFull instead of evicting.
+	ErrorPolicy = FullPolicy{kind: fullError}
+)
+
+// BlockPolicy makes a Put wait until a Delete, a Clear, an expiry or a Resize
+// frees room, failing with ErrCacheFull after timeout. This turns the cache
+// into a bounded buffer. A timeout of zero or less waits indefinitely.
+func BlockPolicy(timeout time.Duration) FullPolicy {
+	return FullPolicy{kind: fullBlock, timeout: timeout}
+}
+
+// WithOnFull selects what happens when a new key is put into a full cache.
+// With ErrorPolicy and BlockPolicy, Put drops a rejected entry silently, so
+// use PutE to see the error. Updates of existing keys, ReplaceAll and a
+// shrinking Resize still evict.
+//
+// Before a Put is rejected or blocked, expired entries are reclaimed: all of
+// them with WithExpirationReaper, whose expiry heap finds them directly, and
+// otherwise those among the expiredScanLimit least recently used entries, so
+// a rejected Put never scans the whole cache.
+func WithOnFull(policy FullPolicy) Option {
+	return func(c *LRUCache) {
+		c.onFull = policy
+	}
+}
+
+// makeRoom applies the full policy before a new entry of cost bytes is put.
+// The caller must hold the write lock, which BlockPolicy releases while waiting.
+func (c *LRUCache) makeRoom(key string, cost int64) error {
+	if c.onFull.kind == fullEvict {
+		return nil
+	}
+	if node, ok := c.Cache[key]; ok && !c.expired(node) {
+		return nil
+	}
+
+	var deadline <-chan time.Time
+	for {
+		if !c.overflows(1, cost) {
+			return nil
+		}
+		// Expired entries are removed lazily, reclaim them before giving up
+		if c.reclaimExpired() > 0 && !c.overflows(1, cost) {
+			return nil
+		}
+		if c.onFull.kind == fullError {
+			return ErrCacheFull
+		}
+
+		if deadline == nil && c.onFull.timeout > 0 {
+			timer := time.NewTimer(c.onFull.timeout)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+		if c.freed == nil {
+			c.freed = make(chan struct{})
+		}
+		freed := c.freed
+
+		c.unlock()
+		select {
+		case <-freed:
+			c.mutex.Lock()
+		case <-deadline:
+			c.mutex.Lock()
+			if c.overflows(1, cost) {
+				return ErrCacheFull
+			}
+			return nil
+		}
+	}
+}
+
+// expiredScanLimit is how many entries from the tail reclaimExpired checks
+// when there is no expiry heap to consult.
+const expiredScanLimit = 64
+
+// reclaimExpired removes expired entries to make room and returns how many it
+// removed: every one due in the reaper's expiry heap, or without a reaper
+// those among the expiredScanLimit entries nearest the tail.
+// The caller must hold the write lock.
+func (c *LRUCache) reclaimExpired() int {
+	removed := 0
+	if r := c.reaper; r != nil {
+		for len(r.heap) > 0 && c.expired(r.heap[0]) {
+			c.removeEntry(r.heap[0], ReasonExpired)
+			removed++
+		}
+		return removed
+	}
+
+	node := c.Tail
+	for i := 0; i < expiredScanLimit && node != nil; i++ {
+		prev := node.Prev
+		if c.expired(node) {
+			c.removeEntry(node, ReasonExpired)
+			removed++
+		}
+		node = prev
+	}
+	return removed
+}
+
+// signalFreed wakes the Puts blocked by BlockPolicy.
+// The caller must hold the write lock.
+func (c *LRUCache) signalFreed() {
+	if c.freed != nil {
+		close(c.freed)
+		c.freed = nil
+	}
+}
+
+// FullSignal returns a channel that receives a value whenever entries are
+// evicted to make room, so a producer can throttle while the cache is under
+// pressure. Sends never block the cache: evictions that happen before the
+// previous signal was received are coalesced into it. Every call returns the
+// same channel until StopFullSignal closes it.
+func (c *LRUCache) FullSignal() <-chan struct{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.fullSignal == nil {
+		c.fullSignal = make(chan struct{}, 1)
+	}
+	return c.fullSignal
+}
+
+// StopFullSignal closes the channel returned by FullSignal and stops signalling.
+func (c *LRUCache) StopFullSignal() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.fullSignal != nil {
+		close(c.fullSignal)
+		c.fullSignal = nil
+	}
+}
+
+// signalFull notifies the FullSignal listener of a capacity eviction.
+// The caller must hold the write lock.
+func (c *LRUCache) signalFull() {
+	select {
+	case c.fullSignal <- struct{}{}:
+	default:
+	}
+}
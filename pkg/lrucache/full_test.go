@@ -0,0 +1,154 @@
+package lrucache
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestEvictPolicy(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithOnFull(EvictPolicy))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	if err := c.PutE("c", "3"); err != nil {
+		t.Fatalf("PutE into a full cache = %v, want eviction", err)
+	}
+	if c.Has("a") || !c.Has("c") {
+		t.Fatal("EvictPolicy did not evict the least recently used key")
+	}
+}
+
+func TestErrorPolicy(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithOnFull(ErrorPolicy))
+	c.Put("a", "1")
+	c.Put("b", "2")
+
+	if err := c.PutE("c", "3"); !errors.Is(err, ErrCacheFull) {
+		t.Fatalf("PutE into a full cache = %v, want ErrCacheFull", err)
+	}
+	c.Put("c", "3")
+	if c.Has("c") || !c.Has("a") || !c.Has("b") {
+		t.Fatal("ErrorPolicy evicted or stored a new key")
+	}
+	if err := c.PutE("a", "updated"); err != nil {
+		t.Fatalf("update in a full cache = %v, want success", err)
+	}
+	c.Delete("b")
+	if err := c.PutE("c", "3"); err != nil {
+		t.Fatalf("PutE after a Delete = %v, want success", err)
+	}
+}
+
+func TestBlockPolicy(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(1, WithOnFull(BlockPolicy(5*time.Second)))
+	c.Put("a", "1")
+
+	result := make(chan error)
+	go func() { result <- c.PutE("b", "2") }()
+	select {
+	case err := <-result:
+		t.Fatalf("PutE into a full cache returned %v without waiting", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	c.Delete("a")
+	if err := <-result; err != nil {
+		t.Fatalf("blocked PutE = %v after a Delete, want success", err)
+	}
+	if !c.Has("b") {
+		t.Fatal("blocked PutE did not store its entry")
+	}
+
+	c, _ = NewLRUCacheWithOptions(1, WithOnFull(BlockPolicy(10*time.Millisecond)))
+	c.Put("a", "1")
+	start := time.Now()
+	if err := c.PutE("b", "2"); !errors.Is(err, ErrCacheFull) {
+		t.Fatalf("PutE = %v after the timeout, want ErrCacheFull", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("PutE gave up after %v, before the timeout", elapsed)
+	}
+}
+
+func TestFullPolicyReclaimsExpired(t *testing.T) {
+	for _, reaper := range []bool{false, true} {
+		t.Run("reaper="+strconv.FormatBool(reaper), func(t *testing.T) {
+			now := time.Unix(1_700_000_000, 0)
+			opts := []Option{WithOnFull(ErrorPolicy), WithClock(func() time.Time { return now })}
+			if reaper {
+				opts = append(opts, WithExpirationReaper())
+			}
+			const size = 4 * expiredScanLimit
+			c, _ := NewLRUCacheWithOptions(size, opts...)
+			defer c.Close()
+
+			// Only the most recently used entry expires, far from the tail.
+			for i := range size - 1 {
+				c.Put(strconv.Itoa(i), "v")
+			}
+			c.PutWithTTL("short", "v", time.Hour)
+			now = now.Add(2 * time.Hour)
+
+			err := c.PutE("new", "v")
+			if reaper {
+				if err != nil {
+					t.Fatalf("PutE = %v, want the expired entry found through the expiry heap", err)
+				}
+			} else if !errors.Is(err, ErrCacheFull) {
+				t.Fatalf("PutE = %v, want ErrCacheFull: the expired entry is beyond the scan limit", err)
+			}
+
+			// Expired entries near the tail are always reclaimed.
+			c.Clear()
+			for i := range size - 1 {
+				c.Put(strconv.Itoa(i), "v")
+			}
+			c.PutWithTTL("tail", "v", time.Hour)
+			c.MoveToBack("tail")
+			now = now.Add(2 * time.Hour)
+			if err := c.PutE("newer", "v"); err != nil {
+				t.Fatalf("PutE = %v, want the expired tail entry reclaimed", err)
+			}
+		})
+	}
+}
+
+func TestFullSignal(t *testing.T) {
+	c, _ := NewLRUCache(2)
+	signal := c.FullSignal()
+	if c.FullSignal() != signal {
+		t.Fatal("FullSignal returned a different channel")
+	}
+
+	// Nobody receives while the cache overflows many times over; Puts must
+	// not block, and the evictions coalesce into one pending signal.
+	for i := range 100 {
+		c.Put(strconv.Itoa(i), "v")
+	}
+	select {
+	case <-signal:
+	default:
+		t.Fatal("no signal after capacity evictions")
+	}
+	select {
+	case <-signal:
+		t.Fatal("evictions were not coalesced into one signal")
+	default:
+	}
+
+	// Deletes are not capacity pressure.
+	c.Delete("99")
+	select {
+	case <-signal:
+		t.Fatal("Delete signalled")
+	default:
+	}
+
+	c.StopFullSignal()
+	if _, ok := <-signal; ok {
+		t.Fatal("StopFullSignal did not close the channel")
+	}
+	c.Put("x", "v")
+	c.Put("y", "v") // evicts without a listener
+	c.StopFullSignal()
+}
@@ -0,0 +1,158 @@
+package lrucache
+
+import (
+	"hash/maphash"
+	"time"
+)
+
+// WithHashIndex maintains an open-addressing index over the entries keyed by
+// HashKey, next to the Cache map, so that GetHashed can look keys up with a
+// precomputed hash instead of having the map hash the key on every call.
+// It costs an extra slot per entry and some bookkeeping on every insert and
+// removal, so it only pays off for long keys read far more often than written.
+func WithHashIndex(enabled bool) Option {
+	return func(c *LRUCache) {
+		if !enabled {
+			c.index = nil
+			return
+		}
+		c.index = &hashIndex{seed: maphash.MakeSeed()}
+		for key, node := range c.Cache {
+			c.index.insert(c.index.hash(key), node)
+		}
+	}
+}
+
+// HashKey returns the hash GetHashed expects for key. It is only stable for
+// this cache instance and is zero unless the cache was built WithHashIndex.
+func (c *LRUCache) HashKey(key string) uint64 {
+	if c.index == nil {
+		return 0
+	}
+	return c.index.hash(c.normalizeKey(key))
+}
+
+// GetHashed is like Get but finds the entry through the hash index using hash,
+// as returned by HashKey for the same key. Without WithHashIndex it falls
+// back to Get.
+func (c *LRUCache) GetHashed(key string, hash uint64) (string, bool) {
+	if c.latency != nil {
+		defer c.latency.get.observe(time.Now())
+	}
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	var node *Node
+	var ok bool
+	if c.index == nil {
+		node, ok = c.Cache[key]
+	} else {
+		node, ok = c.index.find(hash, key)
+	}
+	found, ok := c.hit(key, node, ok)
+	return found.Value, ok
+}
+
+// hashIndex is an open-addressing hash table with linear probing from
+// precomputed hashes to nodes. Removed slots become tombstones until the
+// next rebuild.
+type hashIndex struct {
+	seed       maphash.Seed
+	slots      []hashSlot // length is a power of two
+	used       int        // live slots
+	tombstones int
+}
+
+type hashSlot struct {
+	hash uint64
+	node *Node // nil for an empty slot
+	dead bool  // tombstone left by a removal
+}
+
+// hash hashes a normalized key.
+func (x *hashIndex) hash(key string) uint64 {
+	return maphash.String(x.seed, key)
+}
+
+// find returns the node stored under key with the given hash.
+func (x *hashIndex) find(hash uint64, key string) (*Node, bool) {
+	if len(x.slots) == 0 {
+		return nil, false
+	}
+
+	mask := uint64(len(x.slots) - 1)
+	for i := hash & mask; ; i = (i + 1) & mask {
+		slot := &x.slots[i]
+		if slot.node == nil && !slot.dead {
+			return nil, false
+		}
+		if !slot.dead && slot.hash == hash && slot.node.Key == key {
+			return slot.node, true
+		}
+	}
+}
+
+// insert adds a node under hash, growing the table to keep it at most
+// three quarters full, tombstones included.
+func (x *hashIndex) insert(hash uint64, node *Node) {
+	if (x.used+x.tombstones+1)*4 > len(x.slots)*3 {
+		x.rebuild(max(16, len(x.slots)*2))
+	}
+
+	mask := uint64(len(x.slots) - 1)
+	for i := hash & mask; ; i = (i + 1) & mask {
+		slot := &x.slots[i]
+		if slot.node == nil {
+			if slot.dead {
+				x.tombstones--
+			}
+			*slot = hashSlot{hash: hash, node: node}
+			x.used++
+			return
+		}
+	}
+}
+
+// remove drops node, stored under hash, leaving a tombstone.
+func (x *hashIndex) remove(hash uint64, node *Node) {
+	if len(x.slots) == 0 {
+		return
+	}
+
+	mask := uint64(len(x.slots) - 1)
+	for i := hash & mask; ; i = (i + 1) & mask {
+		slot := &x.slots[i]
+		if slot.node == nil && !slot.dead {
+			return
+		}
+		if slot.node == node {
+			*slot = hashSlot{dead: true}
+			x.used--
+			x.tombstones++
+			return
+		}
+	}
+}
+
+// rebuild rehashes the live slots into a table of size slots, dropping tombstones.
+func (x *hashIndex) rebuild(size int) {
+	// Shrink back if most of the table is tombstones
+	for size > 16 && x.used*4 < size {
+		size /= 2
+	}
+	old := x.slots
+	x.slots = make([]hashSlot, size)
+	x.used, x.tombstones = 0, 0
+	for _, slot := range old {
+		if slot.node != nil {
+			x.insert(slot.hash, slot.node)
+		}
+	}
+}
+
+// reset empties the index.
+func (x *hashIndex) reset() {
+	x.slots = nil
+	x.used, x.tombstones = 0, 0
+}
@@ -0,0 +1,129 @@
+package lrucache
+
+import (
+	"fmt"
+	"time"
+)
+
+// healthSample bounds the nodes Healthcheck inspects from each end of the list.
+const healthSample = 64
+
+// reaperStaleAfter is how long the expiration reaper may go without a sweep
+// before Healthcheck reports it as stuck or dead.
+const reaperStaleAfter = 3 * reaperHeartbeat
+
+// Healthcheck cheaply verifies the cache's internal state and returns a
+// descriptive error if something is wrong: the list and the map must agree on
+// a sample of entries at both ends of the list (all of them in small caches),
+// the size and byte counts must be within their limits, or past them only by
+// pinned entries, the expiration
+// reaper, if any, must have swept within reaperStaleAfter, and the audit log
+// writer, if any, must be keeping up with its queue. It only holds the read
+// lock, for a bounded time regardless of the cache size unless pinned entries
+// hold the cache over a limit.
+func (c *LRUCache) Healthcheck() error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if err := c.checkList(); err != nil {
+		return err
+	}
+	if err := c.checkLimits(); err != nil {
+		return err
+	}
+	if c.reaper != nil {
+		if since := time.Since(c.reaper.lastSweep); since > reaperStaleAfter {
+			return fmt.Errorf("unhealthy: expiration reaper last swept %v ago", since.Round(time.Second))
+		}
+	}
+	if c.audit != nil {
+		if queued := len(c.audit.records); queued > auditQueueSize*9/10 {
+			return fmt.Errorf("unhealthy: audit log writer falling behind, %d of %d records queued", queued, auditQueueSize)
+		}
+	}
+	return nil
+}
+
+// checkLimits checks the size and byte counts against their limits. Pinned
+// entries may hold the cache past them, so an overflow is only an error when
+// the pinned entries do not account for it; working that out walks the list
+// from the tail, and only while the cache is over a limit.
+// The caller must hold the read lock.
+func (c *LRUCache) checkLimits() error {
+	if c.bytes < 0 {
+		return fmt.Errorf("unhealthy: byte count %d outside the limit of %d", c.bytes, c.maxBytes)
+	}
+
+	var entries int
+	var bytes int64
+	if c.Capacity != NoLimit {
+		entries = len(c.Cache) - c.Capacity
+	}
+	if c.maxBytes > 0 {
+		bytes = c.bytes - c.maxBytes
+	}
+	for node := c.Tail; node != nil && (entries > 0 || bytes > 0); node = node.Prev {
+		if node.pinned {
+			entries--
+			bytes -= node.cost
+		}
+	}
+	if entries > 0 {
+		return fmt.Errorf("unhealthy: %d entries over a capacity of %d", len(c.Cache), c.Capacity)
+	}
+	if bytes > 0 {
+		return fmt.Errorf("unhealthy: byte count %d outside the limit of %d", c.bytes, c.maxBytes)
+	}
+	return nil
+}
+
+// checkList walks up to healthSample nodes from each end of the list and
+// checks their links and map entries. When the walks cover the whole list,
+// its length must match the map. The caller must hold the read lock.
+func (c *LRUCache) checkList() error {
+	if (c.Head == nil) != (c.Tail == nil) || (c.Head == nil) != (len(c.Cache) == 0) {
+		return fmt.Errorf("unhealthy: list ends disagree with %d mapped entries", len(c.Cache))
+	}
+	if c.Head == nil {
+		return nil
+	}
+	if c.Head.Prev != nil || c.Tail.Next != nil {
+		return fmt.Errorf("unhealthy: list ends are linked past the end")
+	}
+
+	walked := 0
+	for node := c.Head; node != nil && walked < healthSample; node = node.Next {
+		if err := c.checkNode(node); err != nil {
+			return err
+		}
+		if node.Next != nil && node.Next.Prev != node {
+			return fmt.Errorf("unhealthy: broken list link after key %q", node.Key)
+		}
+		walked++
+	}
+	if walked < healthSample {
+		// The walk reached the tail, so it covered the whole list
+		if walked != len(c.Cache) {
+			return fmt.Errorf("unhealthy: %d listed entries but %d mapped", walked, len(c.Cache))
+		}
+		return nil
+	}
+
+	for node, i := c.Tail, 0; node != nil && i < healthSample; node, i = node.Prev, i+1 {
+		if err := c.checkNode(node); err != nil {
+			return err
+		}
+		if node.Prev != nil && node.Prev.Next != node {
+			return fmt.Errorf("unhealthy: broken list link before key %q", node.Key)
+		}
+	}
+	return nil
+}
+
+// checkNode checks that the map points back at a listed node.
+func (c *LRUCache) checkNode(node *Node) error {
+	if c.Cache[node.Key] != node {
+		return fmt.Errorf("unhealthy: listed key %q is not mapped to its node", node.Key)
+	}
+	return nil
+}
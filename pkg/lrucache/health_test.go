@@ -0,0 +1,76 @@
+package lrucache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthcheck(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	for _, key := range []string{"a", "b", "c"} {
+		c.Put(key, "v")
+	}
+	if err := c.Healthcheck(); err != nil {
+		t.Fatalf("Healthcheck = %v on a healthy cache", err)
+	}
+
+	c.Head.Next.Prev = nil
+	if err := c.Healthcheck(); err == nil || !strings.Contains(err.Error(), "broken list link") {
+		t.Fatalf("Healthcheck = %v, want a broken link error", err)
+	}
+}
+
+func TestHealthcheckPinnedOverflow(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithMaxBytes(200))
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.Put(key, strings.Repeat("v", 40))
+		c.Pin(key)
+	}
+	if c.Size() != 4 {
+		t.Fatalf("Size = %d, want the pins to hold 4 entries", c.Size())
+	}
+	if err := c.Healthcheck(); err != nil {
+		t.Fatalf("Healthcheck = %v with pinned entries past the limits", err)
+	}
+
+	// An unpinned entry on top of them is still evicted by the next Put
+	c.Put("e", "v")
+	if err := c.Healthcheck(); err != nil {
+		t.Fatalf("Healthcheck = %v with one unpinned entry past the pins", err)
+	}
+
+	// Unpinned entries past the capacity are still reported
+	c, _ = NewLRUCache(4)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.Put(key, "v")
+	}
+	c.Pin("a")
+	c.Capacity = 2
+	if err := c.Healthcheck(); err == nil || !strings.Contains(err.Error(), "over a capacity") {
+		t.Fatalf("Healthcheck = %v, want an over capacity error", err)
+	}
+	c.Pin("b")
+	if err := c.Healthcheck(); err != nil {
+		t.Fatalf("Healthcheck = %v once the pins account for the overflow", err)
+	}
+}
+
+func TestHealthcheckReaperRecency(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(4, WithExpirationReaper())
+	defer c.Close()
+	if err := c.Healthcheck(); err != nil {
+		t.Fatalf("Healthcheck = %v right after creation", err)
+	}
+
+	c.mutex.Lock()
+	c.reaper.lastSweep = time.Now().Add(-reaperStaleAfter - time.Second)
+	c.mutex.Unlock()
+	if err := c.Healthcheck(); err == nil || !strings.Contains(err.Error(), "reaper") {
+		t.Fatalf("Healthcheck = %v, want a stale reaper error", err)
+	}
+
+	// Any sweep, even one with nothing to purge, makes it healthy again.
+	c.PutWithTTL("k", "v", time.Millisecond)
+	waitFor(t, "the reaper to sweep", func() bool { return c.Healthcheck() == nil })
+}
@@ -0,0 +1,50 @@
+package lrucache
+
+// fataler is the part of testing.TB the helpers need, also implemented by
+// *rapid.T.
+type fataler interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// checkIntegrity fails unless the list and the map of c agree: the links are
+// consistent in both directions and every node is indexed under its key.
+func checkIntegrity(t fataler, c *LRUCache) {
+	t.Helper()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	n := 0
+	var prev *Node
+	for node := c.Head; node != nil; node = node.Next {
+		if node.Prev != prev {
+			t.Fatalf("node %q: Prev does not point at the previous node", node.Key)
+		}
+		if c.Cache[node.Key] != node {
+			t.Fatalf("node %q is not indexed under its key", node.Key)
+		}
+		prev = node
+		if n++; n > len(c.Cache) {
+			t.Fatalf("list is longer than the map (%d entries)", len(c.Cache))
+		}
+	}
+	if c.Tail != prev {
+		t.Fatalf("Tail is not the last node of the list")
+	}
+	if n != len(c.Cache) {
+		t.Fatalf("list has %d nodes, map has %d entries", n, len(c.Cache))
+	}
+}
+
+// listKeys returns the keys of c from the head to the tail, including expired
+// and soft-deleted entries.
+func listKeys(c *LRUCache) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := []string{}
+	for node := c.Head; node != nil; node = node.Next {
+		keys = append(keys, node.Key)
+	}
+	return keys
+}
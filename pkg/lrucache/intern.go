@@ -0,0 +1,62 @@
+package lrucache
+
+// WithValueInterning stores identical values once: the cache keeps an interning
+// table so that entries with equal values share a single string, reference
+// counted so it is dropped with the last entry using it. This saves memory when
+// many keys hold the same large value, at the cost of a map lookup per write.
+// The byte limit still charges every entry for its value.
+func WithValueInterning(enabled bool) Option {
+	return func(c *LRUCache) {
+		if !enabled {
+			c.interned = nil
+			return
+		}
+		if c.interned == nil {
+			c.interned = make(map[string]*internedValue)
+		}
+	}
+}
+
+// internedValue is a shared value and the number of entries holding it.
+type internedValue struct {
+	value string
+	refs  int
+}
+
+// InternedValues returns the number of distinct values in the interning table.
+func (c *LRUCache) InternedValues() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.interned)
+}
+
+// intern returns the shared copy of value and takes a reference to it.
+// The caller must hold the write lock.
+func (c *LRUCache) intern(value string) string {
+	if c.interned == nil {
+		return value
+	}
+
+	iv, ok := c.interned[value]
+	if !ok {
+		iv = &internedValue{value: value}
+		c.interned[value] = iv
+	}
+	iv.refs++
+	return iv.value
+}
+
+// release drops a reference to an interned value.
+// The caller must hold the write lock.
+func (c *LRUCache) release(value string) {
+	if c.interned == nil {
+		return
+	}
+
+	if iv, ok := c.interned[value]; ok {
+		if iv.refs--; iv.refs == 0 {
+			delete(c.interned, value)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package lrucache
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestValueInterning(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(100, WithValueInterning(true))
+	for i := range 50 {
+		// Build each value separately so they start out as distinct allocations.
+		c.Put("k"+strconv.Itoa(i), strings.Repeat("x", 1024))
+	}
+	if got := c.InternedValues(); got != 1 {
+		t.Fatalf("InternedValues() = %d, want 1 for 50 identical values", got)
+	}
+	first := c.Cache["k0"].Value
+	for i := range 50 {
+		value := c.Cache["k"+strconv.Itoa(i)].Value
+		if unsafe.StringData(value) != unsafe.StringData(first) {
+			t.Fatalf("entry k%d holds its own copy of the value", i)
+		}
+	}
+
+	// The interned value is released with its last reference.
+	c.Put("other", "y")
+	if got := c.InternedValues(); got != 2 {
+		t.Fatalf("InternedValues() = %d, want 2", got)
+	}
+	for i := range 49 {
+		c.Delete("k" + strconv.Itoa(i))
+	}
+	c.Put("k49", "y")
+	if got := c.InternedValues(); got != 1 {
+		t.Fatalf("InternedValues() = %d after the last reference left, want 1", got)
+	}
+	c.Clear()
+	if got := c.InternedValues(); got != 0 {
+		t.Fatalf("InternedValues() = %d after Clear, want 0", got)
+	}
+
+	plain, _ := NewLRUCache(10)
+	plain.Put("a", "v")
+	if got := plain.InternedValues(); got != 0 {
+		t.Fatalf("InternedValues() = %d without interning, want 0", got)
+	}
+}
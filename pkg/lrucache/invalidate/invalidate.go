@@ -0,0 +1,286 @@
+// Package invalidate keeps the local caches of several replicas coherent by
+// broadcasting invalidations over Redis pub/sub.
+//
+// The replica that writes the shared data publishes a delete or clear message
+// after its own Put or Delete, and every replica runs a Subscriber that applies
+// those messages to its local cache. Pub/sub delivery is at most once, so
+// messages carry a per-publisher sequence number and publishers periodically
+// announce their current sequence in an epoch message. A subscriber that sees a
+// gap in the sequence, or loses its connection, clears its whole cache rather
+// than risk serving stale entries.
+package invalidate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+)
+
+// Op is the kind of an invalidation message.
+type Op string
+
+const (
+	OpDelete Op = "delete" // delete Key
+	OpClear  Op = "clear"  // clear the whole cache
+	OpEpoch  Op = "epoch"  // announce the publisher's current sequence, no change
+)
+
+// Message is the JSON payload published on the channel.
+type Message struct {
+	Op     Op     `json:"op"`
+	Key    string `json:"key,omitempty"`
+	Source string `json:"source,omitempty"` // publisher id
+	Seq    uint64 `json:"seq,omitempty"`    // per-publisher sequence number
+}
+
+// Reconnection backoff bounds.
+const (
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Publisher broadcasts invalidations from the writing replica.
+type Publisher struct {
+	client  redis.UniversalClient
+	channel string
+	source  string
+	seq     atomic.Uint64
+}
+
+// NewPublisher creates a publisher on channel with a random publisher id.
+func NewPublisher(client redis.UniversalClient, channel string) (*Publisher, error) {
+	if client == nil {
+		return nil, errors.New("invalid client: must not be nil")
+	}
+	if channel == "" {
+		return nil, errors.New("invalid channel: must not be empty")
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Publisher{client: client, channel: channel, source: hex.EncodeToString(id)}, nil
+}
+
+// Publish broadcasts an OpDelete for key, or an OpClear (key is ignored).
+// Call it after the local Put or Delete has been applied.
+func (p *Publisher) Publish(ctx context.Context, op Op, key string) error {
+	if op != OpDelete && op != OpClear {
+		return errors.New("invalid op: must be delete or clear")
+	}
+	if op == OpClear {
+		key = ""
+	}
+	return p.send(ctx, Message{Op: op, Key: key, Source: p.source, Seq: p.seq.Add(1)})
+}
+
+// RunEpochs publishes an epoch message every interval until ctx is done, so
+// subscribers notice lost messages even when no further invalidation follows.
+// It returns the context error.
+func (p *Publisher) RunEpochs(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// A failed epoch is simply retried on the next tick
+			_ = p.send(ctx, Message{Op: OpEpoch, Source: p.source, Seq: p.seq.Load()})
+		}
+	}
+}
+
+func (p *Publisher) send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return p.client.Publish(ctx, p.channel, payload).Err()
+}
+
+// SubscriberStats counts what a Subscriber observed.
+type SubscriberStats struct {
+	Received   uint64 // messages read from the channel
+	Dropped    uint64 // malformed messages plus messages detected as lost
+	Resyncs    uint64 // full clears triggered by a gap or a reconnection
+	Reconnects uint64 // connection losses
+}
+
+// Subscriber applies invalidation messages to a local cache.
+type Subscriber struct {
+	cache  lrucache.Cache
+	pubsub *redis.PubSub
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mutex sync.Mutex
+	seqs  map[string]uint64 // last sequence seen per publisher
+
+	received   atomic.Uint64
+	dropped    atomic.Uint64
+	resyncs    atomic.Uint64
+	reconnects atomic.Uint64
+}
+
+// NewRedisSubscriber subscribes to channel and applies the invalidations
+// published there to cache from a background goroutine until Close is called.
+// Lost connections are retried with exponential backoff and the cache is
+// cleared once the subscription is back, since messages may have been missed.
+func NewRedisSubscriber(cache lrucache.Cache, client redis.UniversalClient, channel string) (*Subscriber, error) {
+	if cache == nil {
+		return nil, errors.New("invalid cache: must not be nil")
+	}
+	if client == nil {
+		return nil, errors.New("invalid client: must not be nil")
+	}
+	if channel == "" {
+		return nil, errors.New("invalid channel: must not be empty")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pubsub := client.Subscribe(ctx, channel)
+	// Wait for the confirmation so a bad address fails here rather than silently
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		pubsub.Close()
+		return nil, err
+	}
+
+	s := &Subscriber{
+		cache:  cache,
+		pubsub: pubsub,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		seqs:   make(map[string]uint64),
+	}
+	go s.run(ctx)
+	return s, nil
+}
+
+// Close stops the subscriber and releases its connection.
+func (s *Subscriber) Close() error {
+	s.cancel()
+	err := s.pubsub.Close()
+	<-s.done
+	return err
+}
+
+// Stats returns the subscriber counters.
+func (s *Subscriber) Stats() SubscriberStats {
+	return SubscriberStats{
+		Received:   s.received.Load(),
+		Dropped:    s.dropped.Load(),
+		Resyncs:    s.resyncs.Load(),
+		Reconnects: s.reconnects.Load(),
+	}
+}
+
+// run receives messages until ctx is canceled.
+func (s *Subscriber) run(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		msg, err := s.pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.reconnects.Add(1)
+			if !s.reconnect(ctx) {
+				return
+			}
+			continue
+		}
+
+		s.received.Add(1)
+		s.apply(msg.Payload)
+	}
+}
+
+// reconnect waits with exponential backoff until the subscription is usable
+// again, then clears the cache. It returns false if ctx is canceled first.
+func (s *Subscriber) reconnect(ctx context.Context) bool {
+	backoff := minBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+
+		// Ping re-establishes the connection and resubscribes
+		if err := s.pubsub.Ping(ctx); err == nil {
+			s.resync()
+			return true
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// apply decodes a payload and applies it to the cache.
+func (s *Subscriber) apply(payload string) {
+	var msg Message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		s.dropped.Add(1)
+		return
+	}
+
+	switch msg.Op {
+	case OpDelete:
+		if msg.Key == "" {
+			s.dropped.Add(1)
+			return
+		}
+		s.cache.Delete(msg.Key)
+	case OpClear:
+		s.cache.Clear()
+	case OpEpoch:
+	default:
+		s.dropped.Add(1)
+		return
+	}
+	s.track(msg)
+}
+
+// track checks msg against the last sequence seen from its publisher and
+// clears the cache if messages were lost in between.
+func (s *Subscriber) track(msg Message) {
+	if msg.Source == "" {
+		return
+	}
+
+	s.mutex.Lock()
+	last, known := s.seqs[msg.Source]
+	if msg.Seq > last {
+		s.seqs[msg.Source] = msg.Seq
+	}
+	s.mutex.Unlock()
+
+	// An epoch repeats the latest sequence, any other message is the next one
+	expected := last + 1
+	if msg.Op == OpEpoch {
+		expected = last
+	}
+	if known && msg.Seq > expected {
+		s.dropped.Add(msg.Seq - expected)
+		s.resync()
+	}
+}
+
+// resync clears the cache after invalidations may have been missed.
+func (s *Subscriber) resync() {
+	s.resyncs.Add(1)
+	s.cache.Clear()
+}
@@ -0,0 +1,187 @@
+package lrucache
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// JSONCache is an LRU cache of JSON documents that keeps each one both as the
+// raw string and parsed into a T, so readers get the parsed value without
+// unmarshaling it again on every Get.
+//
+// Get returns the stored T itself: when T holds maps, slices or pointers,
+// callers must not modify what they reach through it.
+type JSONCache[T any] struct {
+	capacity int
+	head     *jsonNode[T]
+	tail     *jsonNode[T]
+	cache    map[string]*jsonNode[T]
+	mutex    sync.Mutex
+}
+
+type jsonNode[T any] struct {
+	key   string
+	raw   string
+	value T
+	prev  *jsonNode[T]
+	next  *jsonNode[T]
+}
+
+// NewJSONCache creates a new JSONCache Instance with the specified capacity.
+func NewJSONCache[T any](capacity int) (*JSONCache[T], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &JSONCache[T]{
+		capacity: capacity,
+		cache:    make(map[string]*jsonNode[T]),
+	}, nil
+}
+
+// Get retrieves the parsed value for a given key from the cache.
+func (c *JSONCache[T]) Get(key string) (T, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.cache[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	c.moveToHead(node)
+	return node.value, true
+}
+
+// Raw retrieves the JSON a key was stored with.
+func (c *JSONCache[T]) Raw(key string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.cache[key]
+	if !ok {
+		return "", false
+	}
+	c.moveToHead(node)
+	return node.raw, true
+}
+
+// Put parses raw into a T and stores both, evicting the least recently used
+// entry when the cache is full. If raw does not parse, the error is returned
+// and the cache is left unchanged.
+func (c *JSONCache[T]) Put(key string, raw string) error {
+	var value T
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return err
+	}
+	c.PutParsed(key, raw, value)
+	return nil
+}
+
+// PutParsed stores raw together with value, its already parsed form, for
+// callers that unmarshaled it anyway. raw is not checked against value.
+func (c *JSONCache[T]) PutParsed(key string, raw string, value T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if node, ok := c.cache[key]; ok {
+		node.raw = raw
+		node.value = value
+		c.moveToHead(node)
+		return
+	}
+
+	if len(c.cache) >= c.capacity && c.tail != nil {
+		c.remove(c.tail)
+	}
+
+	node := &jsonNode[T]{key: key, raw: raw, value: value}
+	c.cache[key] = node
+	c.addToHead(node)
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *JSONCache[T]) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	node, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	c.remove(node)
+	return true
+}
+
+// Has checks if the cache contains a specific key.
+func (c *JSONCache[T]) Has(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Size returns the current number of items in the cache.
+func (c *JSONCache[T]) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.cache)
+}
+
+// Clear removes all items from the cache.
+func (c *JSONCache[T]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.head = nil
+	c.tail = nil
+	c.cache = make(map[string]*jsonNode[T])
+}
+
+// remove unlinks a node and drops it from the map.
+func (c *JSONCache[T]) remove(node *jsonNode[T]) {
+	c.unlink(node)
+	delete(c.cache, node.key)
+}
+
+func (c *JSONCache[T]) moveToHead(node *jsonNode[T]) {
+	if c.head == node {
+		return
+	}
+	c.unlink(node)
+	c.addToHead(node)
+}
+
+// unlink removes a node from the doubly linked list.
+func (c *JSONCache[T]) unlink(node *jsonNode[T]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		c.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		c.tail = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+// addToHead adds a node to the head of the doubly linked list.
+func (c *JSONCache[T]) addToHead(node *jsonNode[T]) {
+	node.prev = nil
+	node.next = c.head
+
+	if c.head != nil {
+		c.head.prev = node
+	}
+	c.head = node
+
+	if c.tail == nil {
+		c.tail = node
+	}
+}
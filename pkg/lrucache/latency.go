@@ -0,0 +1,100 @@
+package lrucache
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// latencyBuckets covers durations up to 2^63ns, one bucket per power of two.
+const latencyBuckets = 64
+
+// latencyHistogram is a log-bucketed histogram updated with atomic adds,
+// so recording never allocates nor takes the cache lock.
+type latencyHistogram struct {
+	counts [latencyBuckets]atomic.Uint64
+}
+
+// latencyTracker holds the per-operation histograms.
+type latencyTracker struct {
+	get latencyHistogram
+	put latencyHistogram
+}
+
+// WithLatencyTracking records the duration of every Get and Put, including the
+// time spent waiting for the lock, into log-bucketed histograms exposed as
+// Stats().GetLatency and Stats().PutLatency. When disabled the overhead is a
+// single nil check per operation.
+func WithLatencyTracking(enabled bool) Option {
+	return func(c *LRUCache) {
+		if enabled {
+			c.latency = &latencyTracker{}
+		} else {
+			c.latency = nil
+		}
+	}
+}
+
+// observe records the time elapsed since start.
+func (h *latencyHistogram) observe(start time.Time) {
+	elapsed := time.Since(start)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	h.counts[bits.Len64(uint64(elapsed))%latencyBuckets].Add(1)
+}
+
+// snapshot copies the histogram counts.
+func (h *latencyHistogram) snapshot() LatencySummary {
+	var s LatencySummary
+	for i := range h.counts {
+		s.Buckets[i] = h.counts[i].Load()
+	}
+	return s
+}
+
+// LatencySummary is a snapshot of a latency histogram. Bucket i counts the
+// operations that took less than 2^i nanoseconds (and at least 2^(i-1)).
+type LatencySummary struct {
+	Buckets [latencyBuckets]uint64
+}
+
+// Count returns the number of recorded operations.
+func (s LatencySummary) Count() uint64 {
+	var total uint64
+	for _, n := range s.Buckets {
+		total += n
+	}
+	return total
+}
+
+// Quantile returns an upper bound for the q-th quantile (0 < q <= 1),
+// accurate to within a factor of two.
+func (s LatencySummary) Quantile(q float64) time.Duration {
+	total := s.Count()
+	if total == 0 {
+		return 0
+	}
+
+	rank := uint64(q * float64(total))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, n := range s.Buckets {
+		seen += n
+		if seen >= rank {
+			return time.Duration(uint64(1) << i)
+		}
+	}
+	return time.Duration(1<<63 - 1)
+}
+
+// P50 returns the median latency.
+func (s LatencySummary) P50() time.Duration { return s.Quantile(0.50) }
+
+// P90 returns the 90th percentile latency.
+func (s LatencySummary) P90() time.Duration { return s.Quantile(0.90) }
+
+// P99 returns the 99th percentile latency.
+func (s LatencySummary) P99() time.Duration { return s.Quantile(0.99) }
@@ -0,0 +1,34 @@
+package lrucache
+
+import "errors"
+
+// LazyCache memoizes a loader: values are computed on first access and
+// served from an LRUCache afterwards.
+type LazyCache struct {
+	cache *LRUCache
+}
+
+// NewLazyCache creates a new LazyCache Instance with the specified capacity.
+// The loader is not called until a key is first requested.
+func NewLazyCache(capacity int, loader func(key string) (string, error)) (*LazyCache, error) {
+	if loader == nil {
+		return nil, errors.New("invalid loader: must not be nil")
+	}
+
+	cache, err := NewLRUCacheWithOptions(capacity, WithLoader(loader))
+	if err != nil {
+		return nil, err
+	}
+	return &LazyCache{cache: cache}, nil
+}
+
+// Get returns the value for key, computing it on first access.
+// Concurrent first accesses share a single loader call; errors are not cached.
+func (l *LazyCache) Get(key string) (string, error) {
+	return l.cache.GetOrLoad(key)
+}
+
+// Invalidate forces the value for key to be recomputed on the next Get.
+func (l *LazyCache) Invalidate(key string) {
+	l.cache.Delete(key)
+}
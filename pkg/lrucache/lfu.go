@@ -0,0 +1,228 @@
+package lrucache
+
+import (
+	"container/heap"
+	"math"
+	"sync"
+	"time"
+)
+
+// LFUCache is a Least Frequently Used cache: when full it evicts the entry
+// with the fewest accesses, the least recently used one among ties.
+// Without decay a key that was hot long ago keeps its count forever; see
+// WithLFUDecay.
+type LFUCache struct {
+	capacity int
+	entries  map[string]*lfuEntry
+	heap     lfuHeap
+	mutex    sync.Mutex
+	now      func() time.Time
+	seq      uint64
+
+	// Decay state, only used when decayLog > 0.
+	start    time.Time
+	interval time.Duration
+	decayLog float64 // -ln(factor)
+}
+
+// lfuEntry keeps its count in the log domain so that decay needs no pass over
+// the entries: score = ln(count) + epoch*decayLog, where epoch is the decay
+// period of the last update. Aging every count by the same factor does not
+// change how two entries compare, so scores only need refreshing on access.
+type lfuEntry struct {
+	key   string
+	value string
+	score float64
+	epoch int64
+	seq   uint64 // last access, breaks ties
+	index int    // position in the heap
+}
+
+// LFUOption configures an LFUCache.
+type LFUOption func(*LFUCache)
+
+// WithLFUDecay multiplies every access count by factor once per interval, so
+// keys that stopped being used lose their weight and are eventually evicted
+// in favor of currently active ones. The decay is applied lazily, in constant
+// time per access, so no operation ever walks the whole cache.
+// A factor outside (0, 1) or an interval of zero or less disables decay.
+func WithLFUDecay(interval time.Duration, factor float64) LFUOption {
+	return func(c *LFUCache) {
+		if interval <= 0 || factor <= 0 || factor >= 1 {
+			c.decayLog = 0
+			return
+		}
+		c.interval = interval
+		c.decayLog = -math.Log(factor)
+	}
+}
+
+var _ Cache = (*LFUCache)(nil)
+
+// NewLFUCache creates a new LFUCache Instance with the specified capacity.
+func NewLFUCache(capacity int, opts ...LFUOption) (*LFUCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	c := &LFUCache{
+		capacity: capacity,
+		entries:  make(map[string]*lfuEntry),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.start = c.now()
+	return c, nil
+}
+
+// Get retrieves the value for a given key and counts an access.
+func (c *LFUCache) Get(key string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.touch(entry)
+	return entry.value, true
+}
+
+// Put adds a key-value pair to the cache, evicting the least frequently used
+// entry when full. Updating a key counts as an access.
+func (c *LFUCache) Put(key string, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		c.touch(entry)
+		return
+	}
+
+	if len(c.entries) >= c.capacity {
+		victim := heap.Pop(&c.heap).(*lfuEntry)
+		delete(c.entries, victim.key)
+	}
+
+	c.seq++
+	epoch := c.epoch()
+	entry := &lfuEntry{
+		key:   key,
+		value: value,
+		score: float64(epoch) * c.decayLog, // ln(1) == 0
+		epoch: epoch,
+		seq:   c.seq,
+	}
+	c.entries[key] = entry
+	heap.Push(&c.heap, entry)
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *LFUCache) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	heap.Remove(&c.heap, entry.index)
+	delete(c.entries, key)
+	return true
+}
+
+// Has checks if the cache contains a specific key without counting an access.
+func (c *LFUCache) Has(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Clear removes all items from the cache.
+func (c *LFUCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*lfuEntry)
+	c.heap = nil
+}
+
+// Size returns the current number of items in the cache.
+func (c *LFUCache) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// Count returns the decayed access count of key, starting at 1 on insertion.
+func (c *LFUCache) Count(key string) (float64, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	return math.Exp(entry.score - float64(c.epoch())*c.decayLog), true
+}
+
+// touch counts an access: the count is decayed to the current period and
+// incremented. The caller must hold the mutex.
+func (c *LFUCache) touch(entry *lfuEntry) {
+	epoch := c.epoch()
+	offset := float64(epoch) * c.decayLog
+	count := math.Exp(entry.score - offset)
+
+	c.seq++
+	entry.score = math.Log(count+1) + offset
+	entry.epoch = epoch
+	entry.seq = c.seq
+	heap.Fix(&c.heap, entry.index)
+}
+
+// epoch returns the number of decay periods elapsed since the cache was created.
+func (c *LFUCache) epoch() int64 {
+	if c.decayLog == 0 {
+		return 0
+	}
+	return int64(c.now().Sub(c.start) / c.interval)
+}
+
+// lfuHeap is a min-heap of entries by score, then by last access.
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].score != h[j].score {
+		return h[i].score < h[j].score
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x any) {
+	entry := x.(*lfuEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
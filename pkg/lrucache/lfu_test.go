@@ -0,0 +1,67 @@
+package lrucache
+
+import (
+	"testing"
+	"time"
+)
+
+// idleHotKeySurvives builds an LFU cache where "old" was hot long ago and
+// "a" and "b" are active now, puts a new key, and reports whether "old"
+// survived the eviction.
+func idleHotKeySurvives(t *testing.T, opts ...LFUOption) bool {
+	t.Helper()
+	now := time.Unix(1_700_000_000, 0)
+	c, err := NewLFUCache(3, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.now = func() time.Time { return now }
+	c.start = now
+
+	c.Put("old", "v")
+	for range 1000 {
+		c.Get("old")
+	}
+	c.Put("a", "v")
+	c.Put("b", "v")
+	for range 20 {
+		now = now.Add(time.Minute)
+		for range 5 {
+			c.Get("a")
+			c.Get("b")
+		}
+	}
+
+	c.Put("new", "v")
+	if !c.Has("new") || c.Size() != 3 {
+		t.Fatalf("Put did not evict exactly one key, size %d", c.Size())
+	}
+	return c.Has("old")
+}
+
+func TestLFUDecayEvictsIdleHotKey(t *testing.T) {
+	if !idleHotKeySurvives(t) {
+		t.Fatal("without decay the formerly hot key was evicted, the workload shows nothing")
+	}
+	if idleHotKeySurvives(t, WithLFUDecay(time.Minute, 0.5)) {
+		t.Fatal("with decay the formerly hot, now idle key survived over active ones")
+	}
+}
+
+func TestLFUDecayCount(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLFUCache(2, WithLFUDecay(time.Minute, 0.5))
+	c.now = func() time.Time { return now }
+	c.start = now
+
+	c.Put("k", "v")
+	c.Get("k")
+	c.Get("k")
+	if count, _ := c.Count("k"); count < 2.99 || count > 3.01 {
+		t.Fatalf("Count = %v, want 3", count)
+	}
+	now = now.Add(2 * time.Minute)
+	if count, _ := c.Count("k"); count < 0.74 || count > 0.76 {
+		t.Fatalf("Count after two periods = %v, want 0.75", count)
+	}
+}
@@ -0,0 +1,196 @@
+package lrucache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithLoader makes the cache read-through: GetOrLoad calls fn on a miss
+// and stores the result.
+func WithLoader(fn func(key string) (string, error)) Option {
+	return func(c *LRUCache) {
+		c.loader = func(key string) (string, time.Duration, error) {
+			value, err := fn(key)
+			return value, 0, err
+		}
+	}
+}
+
+// WithTTLLoader is WithLoader for origins that know how long each value
+// stays fresh. fn also returns the TTL to store the value with: 0 uses the
+// WithTTL default, and a negative TTL returns the value from GetOrLoad
+// without caching it.
+func WithTTLLoader(fn func(key string) (value string, ttl time.Duration, err error)) Option {
+	return func(c *LRUCache) {
+		c.loader = fn
+	}
+}
+
+// GetOrLoad returns the cached value for key, calling the configured loader
+// on a miss. Concurrent misses for the same key share a single loader call.
+// Loader errors are returned as is and nothing is cached. A loader panic is
+// recovered and returned to every waiting caller as an error wrapping
+// ErrLoaderPanic.
+// See WithLoaderCircuitBreaker to stop calling a failing loader, and
+// WithServeStaleOnError to fall back to an expired value.
+func (c *LRUCache) GetOrLoad(key string) (string, error) {
+	value, _, err := c.GetOrLoadStale(key)
+	return value, err
+}
+
+// WithRefreshAhead reloads hot entries in the background shortly before they
+// expire, so readers keep hitting. When a lookup hits an entry whose remaining
+// TTL has dropped below fraction of the TTL it was stored with, the configured
+// loader is called asynchronously and the current value is returned meanwhile.
+// At most one refresh per entry is in flight, and loader errors leave the
+// entry to expire as usual. Needs WithLoader and a TTL; fractions outside
+// (0, 1) disable it.
+func WithRefreshAhead(fraction float64) Option {
+	return func(c *LRUCache) {
+		if fraction > 0 && fraction < 1 {
+			c.refreshAhead = fraction
+		}
+	}
+}
+
+// WithSlowLoaderThreshold reports loader calls that take longer than d, to
+// surface a misbehaving upstream. Each one increments Stats.SlowLoads and is
+// passed to fn, or logged at Warn level to the WithLogger logger when fn is
+// nil. Durations are measured on the monotonic clock, and fn runs on the
+// loading goroutine outside the cache lock, so it may call back into the cache.
+func WithSlowLoaderThreshold(d time.Duration, fn func(key string, elapsed time.Duration)) Option {
+	return func(c *LRUCache) {
+		c.slowLoad = d
+		c.onSlowLoad = fn
+	}
+}
+
+func (c *LRUCache) reportSlowLoad(key string, elapsed time.Duration) {
+	c.slowLoads.Add(1)
+	if c.onSlowLoad != nil {
+		c.onSlowLoad(key, elapsed)
+		return
+	}
+	c.logSlowLoad(key, elapsed)
+}
+
+// refreshIfDue starts a background reload of a node that hit close to its
+// expiry. The caller must hold the write lock.
+func (c *LRUCache) refreshIfDue(node *Node) {
+	if c.refreshAhead == 0 || c.loader == nil || node.expiresAt.IsZero() || node.refreshing {
+		return
+	}
+	ttl := node.expiresAt.Sub(node.storedAt)
+	if node.expiresAt.Sub(c.now()) >= time.Duration(float64(ttl)*c.refreshAhead) {
+		return
+	}
+
+	node.refreshing = true
+	key := node.Key
+	go func() {
+		_, _ = c.load(key)
+
+		c.mutex.Lock()
+		node.refreshing = false
+		c.mutex.Unlock()
+	}()
+}
+
+// load calls the loader for key and stores the result, sharing the call
+// with concurrent loads of the same key.
+func (c *LRUCache) load(key string) (string, error) {
+	return c.loads.do(key, func() (string, error) {
+		if c.breaker != nil {
+			if !c.breaker.allow(c.now()) {
+				return "", ErrLoaderBreakerOpen
+			}
+		}
+		start := time.Now()
+		value, ttl, err := c.callLoader(key)
+		if elapsed := time.Since(start); c.slowLoad > 0 && elapsed > c.slowLoad {
+			c.reportSlowLoad(key, elapsed)
+		}
+		if c.breaker != nil {
+			c.breaker.done(err, c.now())
+		}
+		if err != nil {
+			if c.onLoadError != nil {
+				c.onLoadError(key, err)
+			}
+			return "", err
+		}
+		c.storeLoaded(key, value, ttl)
+		return value, nil
+	})
+}
+
+// callLoader calls the loader, turning a panic into an error so the
+// circuit breaker and WithOnLoadError see it as a failure.
+func (c *LRUCache) callLoader(key string) (value string, ttl time.Duration, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrLoaderPanic, r)
+		}
+	}()
+	return c.loader(key)
+}
+
+// storeLoaded stores a loaded value with the TTL its origin reported, following
+// the WithTTLLoader convention: 0 uses the default TTL and a negative TTL
+// skips caching.
+func (c *LRUCache) storeLoaded(key string, value string, ttl time.Duration) {
+	switch {
+	case ttl == 0:
+		ttl = c.ttl
+	case ttl < 0:
+		return
+	}
+	_ = c.set(key, value, nil, ttl)
+}
+
+// group deduplicates concurrent calls for the same key.
+type group struct {
+	mutex sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	wg    sync.WaitGroup
+	value string
+	err   error
+}
+
+// do runs fn once per key at a time; callers arriving while it runs
+// wait for and share its result. If fn panics, the panic is returned to
+// all of them as an error wrapping ErrLoaderPanic.
+func (g *group) do(key string, fn func() (string, error)) (string, error) {
+	g.mutex.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if cl, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		cl.wg.Wait()
+		return cl.value, cl.err
+	}
+
+	cl := &call{}
+	cl.wg.Add(1)
+	g.calls[key] = cl
+	g.mutex.Unlock()
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				cl.value, cl.err = "", fmt.Errorf("%w: %v", ErrLoaderPanic, r)
+			}
+			g.mutex.Lock()
+			delete(g.calls, key)
+			g.mutex.Unlock()
+			cl.wg.Done()
+		}()
+		cl.value, cl.err = fn()
+	}()
+	return cl.value, cl.err
+}
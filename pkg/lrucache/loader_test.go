@@ -0,0 +1,166 @@
+package lrucache
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// atomicClock is a fake clock that background goroutines may read.
+type atomicClock struct{ unix atomic.Int64 }
+
+func (c *atomicClock) now() time.Time      { return time.Unix(c.unix.Load(), 0) }
+func (c *atomicClock) add(d time.Duration) { c.unix.Add(int64(d / time.Second)) }
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRefreshAhead(t *testing.T) {
+	clock := &atomicClock{}
+	clock.unix.Store(1_700_000_000)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	c, _ := NewLRUCacheWithOptions(4,
+		WithClock(clock.now),
+		WithTTL(10*time.Minute),
+		WithRefreshAhead(0.2),
+		WithLoader(func(key string) (string, error) {
+			n := loads.Add(1)
+			if n > 1 {
+				<-release
+			}
+			return "v" + strconv.Itoa(int(n)), nil
+		}),
+	)
+	if value, err := c.GetOrLoad("k"); err != nil || value != "v1" {
+		t.Fatalf("GetOrLoad = %q, %v, want v1", value, err)
+	}
+
+	// Well before expiry, hits do not refresh.
+	clock.add(5 * time.Minute)
+	c.Get("k")
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("%d loads before the refresh window, want 1", n)
+	}
+
+	// Inside the last 20% of the TTL, hits keep returning the current value
+	// while exactly one refresh runs.
+	clock.add(4 * time.Minute)
+	for range 5 {
+		if value, ok := c.Get("k"); !ok || value != "v1" {
+			t.Fatalf("Get during refresh = %q, %v, want v1", value, ok)
+		}
+	}
+	waitFor(t, "the refresh to start", func() bool { return loads.Load() == 2 })
+	close(release)
+	waitFor(t, "the refreshed value", func() bool {
+		value, _ := c.Get("k")
+		return value == "v2"
+	})
+	if n := loads.Load(); n != 2 {
+		t.Fatalf("%d loads, want exactly one refresh", n)
+	}
+
+	// The refreshed entry got a new TTL, so it outlives the original expiry.
+	clock.add(2 * time.Minute)
+	if value, ok := c.Get("k"); !ok || value != "v2" {
+		t.Fatalf("Get after the original expiry = %q, %v, want v2", value, ok)
+	}
+}
+
+func TestServeStaleOnError(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	failing := errors.New("origin down")
+	var loadErr error
+	var reported []error
+	c, _ := NewLRUCacheWithOptions(4,
+		WithClock(func() time.Time { return now }),
+		WithTTL(time.Minute),
+		WithServeStaleOnError(true),
+		WithOnLoadError(func(key string, err error) { reported = append(reported, err) }),
+		WithLoader(func(key string) (string, error) {
+			if loadErr != nil {
+				return "", loadErr
+			}
+			return "fresh", nil
+		}),
+	)
+	if _, err := c.GetOrLoad("k"); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Hour)
+	loadErr = failing
+	value, stale, err := c.GetOrLoadStale("k")
+	if err != nil || !stale || value != "fresh" {
+		t.Fatalf("GetOrLoadStale = %q, %v, %v, want the stale value without an error", value, stale, err)
+	}
+	if value, err := c.GetOrLoad("k"); err != nil || value != "fresh" {
+		t.Fatalf("GetOrLoad = %q, %v, want the stale value", value, err)
+	}
+	if len(reported) != 2 || !errors.Is(reported[0], failing) {
+		t.Fatalf("OnLoadError got %v, want the loader error twice", reported)
+	}
+	if n := c.Stats().StaleServed; n != 2 {
+		t.Fatalf("StaleServed = %d, want 2", n)
+	}
+
+	// A key with nothing stale to serve still returns the error.
+	if _, err := c.GetOrLoad("other"); !errors.Is(err, failing) {
+		t.Fatalf("GetOrLoad(other) error = %v, want the loader error", err)
+	}
+
+	// Once the loader recovers, the reload replaces the stale value.
+	loadErr = nil
+	if value, stale, err := c.GetOrLoadStale("k"); err != nil || stale || value != "fresh" {
+		t.Fatalf("GetOrLoadStale after recovery = %q, %v, %v", value, stale, err)
+	}
+}
+
+func TestTTLLoader(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ttls := map[string]time.Duration{"default": 0, "short": time.Minute, "uncached": -1}
+	loads := make(map[string]int)
+	c, _ := NewLRUCacheWithOptions(4,
+		WithClock(func() time.Time { return now }),
+		WithTTL(time.Hour),
+		WithTTLLoader(func(key string) (string, time.Duration, error) {
+			loads[key]++
+			return "v-" + key, ttls[key], nil
+		}),
+	)
+
+	for range 2 {
+		if value, err := c.GetOrLoad("uncached"); err != nil || value != "v-uncached" {
+			t.Fatalf("GetOrLoad(uncached) = %q, %v, want the loaded value", value, err)
+		}
+	}
+	if c.Has("uncached") || loads["uncached"] != 2 {
+		t.Fatalf("a negative TTL was cached: present %v, %d loads", c.Has("uncached"), loads["uncached"])
+	}
+
+	c.GetOrLoad("default")
+	c.GetOrLoad("short")
+	now = now.Add(2 * time.Minute)
+	if c.Has("short") {
+		t.Fatal("the loader's TTL was not applied")
+	}
+	if !c.Has("default") {
+		t.Fatal("a zero TTL did not fall back to the default TTL")
+	}
+	now = now.Add(time.Hour)
+	if c.Has("default") {
+		t.Fatal("a zero TTL did not expire with the default TTL")
+	}
+}
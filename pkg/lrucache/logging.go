@@ -0,0 +1,113 @@
+package lrucache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithLogger logs cache decisions to logger, using structured attributes:
+// evictions, rejected puts and expiration reaper sweeps at Debug level, and
+// EvictOlderThan sweeps and stream exports and imports at Info level.
+// Records produced under the cache lock are emitted after it is released, so
+// a slow handler does not stall other goroutines.
+// Values are left out of the log records unless WithLogValues is set.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *LRUCache) {
+		c.logger = logger
+	}
+}
+
+// WithLogValues includes entry values in log records.
+// Off by default since cached values frequently hold personal data.
+func WithLogValues(enabled bool) Option {
+	return func(c *LRUCache) {
+		c.logValues = enabled
+	}
+}
+
+// logEviction records a single eviction at Debug level.
+func (c *LRUCache) logEviction(e eviction) {
+	if c.logger == nil || !c.logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("key", e.key),
+		slog.String("reason", e.reason.String()),
+		slog.Duration("age", e.age),
+	}
+	if c.logValues {
+		attrs = append(attrs, slog.String("value", e.value))
+	}
+	c.logger.LogAttrs(context.Background(), slog.LevelDebug, "lrucache: evicted", attrs...)
+}
+
+// logRejected records a put that was refused at Debug level.
+func (c *LRUCache) logRejected(key string, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(context.Background(), slog.LevelDebug, "lrucache: rejected put",
+		slog.String("key", key),
+		slog.String("error", err.Error()),
+	)
+}
+
+// logSlowLoad records a loader call over the slow threshold at Warn level.
+func (c *LRUCache) logSlowLoad(key string, elapsed time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(context.Background(), slog.LevelWarn, "lrucache: slow load",
+		slog.String("key", key),
+		slog.Duration("elapsed", elapsed),
+	)
+}
+
+// sweep is a bulk removal pass waiting to be logged.
+type sweep struct {
+	source  string // what ran the pass, e.g. "reaper"
+	level   slog.Level
+	purged  int
+	elapsed time.Duration
+}
+
+// queueSweep queues a bulk removal pass to be logged once the write lock is
+// released. The caller must hold the write lock.
+func (c *LRUCache) queueSweep(source string, level slog.Level, purged int, elapsed time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	c.sweeps = append(c.sweeps, sweep{source: source, level: level, purged: purged, elapsed: elapsed})
+}
+
+// logSweep records a bulk removal pass.
+func (c *LRUCache) logSweep(s sweep) {
+	c.logger.LogAttrs(context.Background(), s.level, "lrucache: sweep",
+		slog.String("source", s.source),
+		slog.Int("purged", s.purged),
+		slog.Duration("duration", s.elapsed),
+	)
+}
+
+// logStream records a finished ExportStream or ImportStream at Info level,
+// or at Error level if it failed.
+func (c *LRUCache) logStream(op string, entries int, elapsed time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.Int("entries", entries),
+		slog.Duration("duration", elapsed),
+	}
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(context.Background(), level, "lrucache: "+op, attrs...)
+}
@@ -0,0 +1,132 @@
+package lrucache
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// logRecord is a log record reduced to what the tests check.
+type logRecord struct {
+	message string
+	attrs   map[string]any
+}
+
+// captureHandler keeps the records it handles. If cache is set, it calls
+// back into it, which deadlocks if the record is logged under the lock.
+type captureHandler struct {
+	mutex   sync.Mutex
+	cache   *LRUCache
+	records []logRecord
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *captureHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	if h.cache != nil {
+		h.cache.Size()
+	}
+	rec := logRecord{message: r.Message, attrs: make(map[string]any)}
+	r.Attrs(func(a slog.Attr) bool {
+		rec.attrs[a.Key] = a.Value.Any()
+		return true
+	})
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.records = append(h.records, rec)
+	return nil
+}
+
+// find returns the first record with the given message and source attribute,
+// ignoring the source when it is empty.
+func (h *captureHandler) find(message, source string) (logRecord, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, rec := range h.records {
+		if rec.message == message && (source == "" || rec.attrs["source"] == source) {
+			return rec, true
+		}
+	}
+	return logRecord{}, false
+}
+
+func TestLogSweepOutsideLock(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	h := &captureHandler{}
+	c, _ := NewLRUCacheWithOptions(10, WithLogger(slog.New(h)), WithClock(func() time.Time { return now }))
+	h.cache = c
+	c.Put("a", "1")
+	c.Put("b", "2")
+	now = now.Add(time.Hour)
+
+	c.EvictOlderThan(time.Minute)
+	rec, ok := h.find("lrucache: sweep", "evict_older_than")
+	if !ok {
+		t.Fatal("EvictOlderThan sweep was not logged")
+	}
+	if rec.attrs["purged"] != int64(2) {
+		t.Fatalf("purged = %v, want 2", rec.attrs["purged"])
+	}
+}
+
+func TestLogReaperSweep(t *testing.T) {
+	h := &captureHandler{}
+	c, _ := NewLRUCacheWithOptions(10, WithLogger(slog.New(h)), WithExpirationReaper())
+	defer c.Close()
+	h.cache = c
+	c.PutWithTTL("a", "1", time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if rec, ok := h.find("lrucache: sweep", "reaper"); ok {
+			if rec.attrs["purged"] != int64(1) {
+				t.Fatalf("purged = %v, want 1", rec.attrs["purged"])
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("reaper sweep was not logged")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLogStream(t *testing.T) {
+	h := &captureHandler{}
+	src, _ := NewLRUCacheWithOptions(10, WithLogger(slog.New(h)))
+	dst, _ := NewLRUCacheWithOptions(10, WithLogger(slog.New(h)))
+	src.Put("a", "1")
+	src.Put("b", "2")
+
+	var buf bytes.Buffer
+	if err := src.ExportStream(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.ImportStream(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, message := range []string{"lrucache: export", "lrucache: import"} {
+		rec, ok := h.find(message, "")
+		if !ok {
+			t.Fatalf("%q was not logged", message)
+		}
+		if rec.attrs["entries"] != int64(2) {
+			t.Fatalf("%s entries = %v, want 2", message, rec.attrs["entries"])
+		}
+	}
+
+	if err := dst.ImportStream(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Fatal("ImportStream accepted a bad stream")
+	}
+	h.mutex.Lock()
+	last := h.records[len(h.records)-1]
+	h.mutex.Unlock()
+	if last.message != "lrucache: import" || last.attrs["error"] == nil {
+		t.Fatalf("failed import logged as %+v, want an error attribute", last)
+	}
+}
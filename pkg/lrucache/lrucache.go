@@ -1,8 +1,11 @@
 package lrucache
 
 import (
-	"errors"
+	"log/slog"
+	"math/rand/v2"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // LRUCache implements a Least Recently Used (LRU) cache.
@@ -14,6 +17,22 @@ type Node struct {
 	Value string
 	Prev  *Node
 	Next  *Node
+
+	protected   bool              // true while the node sits in the protected segment
+	accessedAt  time.Time         // last insertion or promotion
+	storedAt    time.Time         // last insertion or update of the value
+	expiresAt   time.Time         // zero when the entry never expires
+	cost        int64             // key, value and metadata bytes charged against maxBytes
+	accesses    uint64            // hits since insertion
+	tailMark    bool              // sampled in the tail segment by the auto-tuner
+	meta        map[string]string // caller metadata, see PutWithMeta
+	deleted     bool              // soft-deleted, removed by Compact
+	version     uint64            // write sequence number, see GetVersioned
+	unpromoted  int               // hits since the last move to the head, see WithPromotionInterval
+	refreshing  bool              // a refresh-ahead reload is in flight
+	expiryIndex int               // position in the expiry heap plus one, see WithExpirationReaper
+	read        bool              // hit since the last write, see Stats.UnreadRatio
+	pinned      bool              // exempt from capacity eviction, see Pin
 }
 
 type LRUCache struct {
@@ -21,38 +40,261 @@ type LRUCache struct {
 	Head     *Node
 	Tail     *Node
 	Cache    map[string]*Node
-	mutex    sync.RWMutex
+	mutex    locker    // a *sync.RWMutex unless WithConcurrency says otherwise
+	reads    *readLock // the mutex under StrategyAtomicReads, nil otherwise
+
+	normalize   func(string) string // optional key normalizer, identity when nil
+	validate    func(string) error  // optional key validator, see WithKeyValidator
+	now         func() time.Time
+	ttl         time.Duration // default time to live, zero for no expiry
+	maxBytes    int64         // byte limit on stored entries, zero for no limit
+	maxKeyBytes int           // longest key accepted, zero for no limit
+	bytes       int64
+	writes      uint64                    // last version handed out
+	interned    map[string]*internedValue // nil unless WithValueInterning is set
+	lookback    int                       // tail entries considered per eviction
+	promotion   int                       // hits per move to the head, see WithPromotionInterval
+	promotionP  float64                   // chance a hit moves to the head, see WithPromotionProbability
+	onFull      FullPolicy
+	evictMRU    bool                   // evict from the head, see NewMRUCache
+	reserve     *reservations          // nil unless ReserveSlots was called
+	freed       chan struct{}          // closed when room frees up, see BlockPolicy
+	fullSignal  chan struct{}          // nil unless FullSignal was called
+	waiters     map[string]*keyWaiters // WaitForKey calls by key
+
+	index  *hashIndex // nil unless WithHashIndex is set
+	reaper *reaper    // nil unless WithExpirationReaper is set
+
+	loader       func(key string) (string, time.Duration, error) // see WithTTLLoader
+	loads        group
+	refreshAhead float64 // fraction of the TTL left when a hit triggers a reload, see WithRefreshAhead
+	slowLoad     time.Duration
+	onSlowLoad   func(key string, elapsed time.Duration)
+	slowLoads    atomic.Uint64 // updated outside the lock
+	serveStale   bool
+	staleServed  atomic.Uint64 // updated outside the lock
+	onLoadError  func(key string, err error)
+	breaker      *breaker // nil unless WithLoaderCircuitBreaker is set
+
+	onEvict       func(key, value string, reason EvictionReason)
+	victimCache   Cache         // nil unless WithVictimCache is set
+	victimHits    atomic.Uint64 // updated outside the lock
+	pending       []eviction    // callbacks queued until the write lock is released
+	sweeps        []sweep       // sweep log records queued like pending
+	secureErase   bool
+	eraseKeys     bool
+	notifyOnClear bool
+
+	logger    *slog.Logger
+	logValues bool
+
+	hits               uint64
+	misses             uint64
+	evictions          uint64
+	rejected           uint64 // puts refused by the key validator
+	auditDropped       uint64 // audit records dropped on a full queue
+	evictedUnread      uint64 // entries evicted or expired without a hit since their last write
+	overwrittenUnread  uint64 // writes replaced without a hit
+	window             [windowSeconds]bucket
+	thrashMissRate     float64
+	thrashEvictionRate float64
+
+	profiler atomic.Pointer[Profiler]
+	events   *eventLog
+
+	recent     [recentEvictionsSize]EventRecord
+	recentNext int
+
+	tuner   *autoTuner
+	latency *latencyTracker
+	audit   *auditLog
+	replica *replication // nil unless WithReplicaTo is set
+
+	// Segmented LRU state, only used when probationaryFraction > 0.
+	probationaryFraction float64
+	protectedCap         int
+	protectedLen         int
+	probation            *Node // first (most recent) probationary node
 }
 
 // NewLRUCache creates a new LRUCache Instance with the specified capacity.
 func NewLRUCache(capacity int) (*LRUCache, error) {
 	if capacity <= 0 {
-		return nil, errors.New("invalid capacity: must be greater than 0")
+		return nil, ErrInvalidCapacity
 	}
+	return newLRUCache(capacity), nil
+}
+
+// NewMRUCache creates a cache that evicts the most recently used entry
+// instead of the least recently used one when full, for workloads where an
+// item just accessed is the least likely to be requested again soon, such as
+// cyclic scans larger than the cache. Hits and puts still move entries to the
+// head, which is where evictions now happen. WithSegments and
+// WithEvictionLookback have no effect on the choice of victim.
+func NewMRUCache(capacity int, opts ...Option) (*LRUCache, error) {
+	cache, err := NewLRUCacheWithOptions(capacity, opts...)
+	if err != nil {
+		return nil, err
+	}
+	cache.evictMRU = true
+	return cache, nil
+}
+
+// NoLimit is the Capacity of a cache created with NewUnboundedLRUCache.
+const NoLimit = 0
 
+// NewUnboundedLRUCache creates an LRUCache with no entry limit, for short-lived
+// jobs that never want eviction and Clear the cache when done. A Put never
+// evicts to make room, though WithMaxBytes still applies. Resize to a positive
+// capacity turns it into a regular bounded cache and trims it to that size.
+// WithAutoTune has no effect on an unbounded cache. NewLRUCache(0) still
+// fails, so unbounded behaviour always needs this constructor.
+func NewUnboundedLRUCache(opts ...Option) *LRUCache {
+	cache := newLRUCache(NoLimit)
+	for _, opt := range opts {
+		opt(cache)
+	}
+	return cache
+}
+
+func newLRUCache(capacity int) *LRUCache {
 	return &LRUCache{
 		Capacity: capacity,
 		Head:     nil,
 		Tail:     nil,
 		Cache:    make(map[string]*Node),
-		mutex:    sync.RWMutex{},
-	}, nil
+		mutex:    new(sync.RWMutex),
+		now:      time.Now,
+		lookback: 1,
+
+		thrashMissRate:     0.9,
+		thrashEvictionRate: 0.5,
+	}
 }
 
 // Get retrieves the value for a given key from the cache.
 // Returns the value and true if found, empty string and false otherwise.
 func (c *LRUCache) Get(key string) (string, bool) {
+	if c.latency != nil {
+		defer c.latency.get.observe(time.Now())
+	}
+	return c.get(c.normalizeKey(key))
+}
+
+// GetE is like Get but returns ErrKeyNotFound on a miss, for callers that
+// handle misses as errors.
+func (c *LRUCache) GetE(key string) (string, error) {
+	value, ok := c.Get(key)
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+// get looks up an already normalized key.
+func (c *LRUCache) get(key string) (string, bool) {
+	node, ok := c.lookup(key)
+	if !ok && c.victimCache != nil {
+		return c.fromVictim(key)
+	}
+	return node.Value, ok
+}
+
+// lookup looks up an already normalized key like get, and returns a copy
+// of its node taken under the lock.
+func (c *LRUCache) lookup(key string) (Node, bool) {
+	if c.reads != nil {
+		if node, ok, served := c.readSnapshot(key); served {
+			return node, ok
+		}
+	}
+
 	c.mutex.Lock() // Use write lock since we modify the list order
-	defer c.mutex.Unlock()
-	if node, ok := c.Cache[key]; ok {
+	defer c.unlock()
+	node, ok := c.Cache[key]
+	found, ok := c.hit(key, node, ok)
+	if c.reads != nil {
+		c.publishReads()
+	}
+	return found, ok
+}
+
+// hit completes a lookup of key that found node (if ok): it promotes the node
+// or removes it if expired, and records the hit or miss.
+// The caller must hold the write lock.
+func (c *LRUCache) hit(key string, node *Node, ok bool) (Node, bool) {
+	if ok && !node.deleted {
+		if c.expired(node) {
+			c.removeEntry(node, ReasonExpired)
+			c.recordLookup(key, false)
+			c.tuneMiss(key)
+			return Node{}, false
+		}
 		// Move the accessed node to the head of the list
-		c.moveToHead(node)
-		return node.Value, true
+		c.promoteHit(node)
+		c.refreshIfDue(node)
+		node.accesses++
+		node.read = true
+		c.recordLookup(key, true)
+		c.tuneHit(node)
+		return *node, true
+	}
+	c.recordLookup(key, false)
+	c.tuneMiss(key)
+	return Node{}, false
+}
+
+// GetWithAge is like Get but also returns how long ago the value was stored,
+// so callers can decide to refresh it early. Updating a key restarts its age.
+func (c *LRUCache) GetWithAge(key string) (value string, age time.Duration, ok bool) {
+	if c.latency != nil {
+		defer c.latency.get.observe(time.Now())
+	}
+	node, ok := c.lookup(c.normalizeKey(key))
+	if !ok {
+		return "", 0, false
+	}
+	return node.Value, c.now().Sub(node.storedAt), true
+}
+
+// GetWithCost is like Get but also returns the entry's cost: the key, value
+// and metadata bytes exactly as stored and charged against WithMaxBytes.
+// This is the accounted size, not the size of what the caller originally
+// put; a cache that transforms values before storing them, such as the one
+// behind an EncryptedCache, reports the size of the stored form.
+func (c *LRUCache) GetWithCost(key string) (value string, cost int64, ok bool) {
+	if c.latency != nil {
+		defer c.latency.get.observe(time.Now())
 	}
-	return "", false
+	node, ok := c.lookup(c.normalizeKey(key))
+	return node.Value, node.cost, ok
+}
+
+// promoteHit moves a node that was hit to the head, or only counts the hit
+// while it is below the promotion interval or not sampled for promotion.
+func (c *LRUCache) promoteHit(node *Node) {
+	if c.promotionP > 0 && rand.Float64() >= c.promotionP {
+		node.accessedAt = c.now()
+		return
+	}
+	if c.promotion > 1 {
+		if node.unpromoted++; node.unpromoted < c.promotion {
+			node.accessedAt = c.now()
+			return
+		}
+	}
+	c.moveToHead(node)
 }
 
 func (c *LRUCache) moveToHead(node *Node) {
+	node.accessedAt = c.now()
+	node.unpromoted = 0
+
+	if c.probationaryFraction > 0 {
+		c.promote(node)
+		return
+	}
+
 	if c.Head == node {
 		return
 	}
@@ -66,6 +308,14 @@ func (c *LRUCache) moveToHead(node *Node) {
 
 // removeNode removes a node from the doubly linked list.
 func (c *LRUCache) removeNode(node *Node) {
+	if node == c.probation {
+		c.probation = node.Next
+	}
+	if node.protected {
+		node.protected = false
+		c.protectedLen--
+	}
+
 	if node.Prev != nil {
 		node.Prev.Next = node.Next
 	} else {
@@ -93,68 +343,412 @@ func (c *LRUCache) addToHead(node *Node) {
 	}
 }
 
-// removeTail removes the least recently used item (tail) from the cache.
-func (c *LRUCache) removeTail() *Node {
-	if c.Tail == nil {
-		return nil
+// addToTail adds a node to the tail of the doubly linked list.
+func (c *LRUCache) addToTail(node *Node) {
+	node.Next = nil
+	node.Prev = c.Tail
+
+	if c.Tail != nil {
+		c.Tail.Next = node
+	}
+	c.Tail = node
+
+	if c.Head == nil {
+		c.Head = node
 	}
+}
+
+// insertBefore links node into the list directly in front of mark.
+func (c *LRUCache) insertBefore(node, mark *Node) {
+	node.Prev = mark.Prev
+	node.Next = mark
 
-	tailNode := c.Tail
-	c.removeNode(tailNode)
-	return tailNode
+	if mark.Prev != nil {
+		mark.Prev.Next = node
+	} else {
+		c.Head = node
+	}
+	mark.Prev = node
 }
 
 // Put adds a key-value pair to the cache.
 // If the key already exists, it updates the value and moves the node to the head.
+// Entries rejected by the byte limit or the WithOnFull policy are dropped
+// silently; use PutE to see why.
 func (c *LRUCache) Put(key string, value string) {
+	_ = c.set(c.normalizeKey(key), value, nil, c.ttl)
+}
+
+// PutE is like Put but returns an error when the entry is rejected.
+func (c *LRUCache) PutE(key string, value string) error {
+	return c.set(c.normalizeKey(key), value, nil, c.ttl)
+}
+
+// TryPut is like Put but reports whether the entry is in the cache after the
+// call, for callers that do not need the reason of a rejection: an oversized
+// key or entry, a full cache under WithOnFull, and so on. Use PutE for the error.
+func (c *LRUCache) TryPut(key string, value string) bool {
+	return c.PutE(key, value) == nil
+}
+
+// set stores an already normalized key under the write lock.
+func (c *LRUCache) set(key string, value string, meta map[string]string, ttl time.Duration) error {
+	if c.latency != nil {
+		defer c.latency.put.observe(time.Now())
+	}
+
 	// Lock the cache for writing to ensure thread safety
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	evictions := c.evictions
+	err := c.makeRoom(key, entryCost(key, value, meta))
+	if err == nil {
+		err = c.put(key, value, meta, ttl)
+	}
+	evicted := c.evictions != evictions
+	c.unlock()
+
+	if p := c.profiler.Load(); p != nil {
+		p.record(ProfileEvent{Op: "put", Key: key, Evicted: evicted, Time: c.now()})
+	}
+	if err != nil {
+		c.recordEvent("put", key, "rejected")
+	} else {
+		c.recordEvent("put", key, "stored")
+	}
+
+	if err != nil {
+		c.logRejected(key, err)
+	}
+	return err
+}
+
+// put inserts or updates a key-value pair. The caller must hold the write lock.
+func (c *LRUCache) put(key string, value string, meta map[string]string, ttl time.Duration) error {
+	meta = copyMeta(meta)
+	cost := entryCost(key, value, meta)
+	if err := c.checkEntry(key, cost); err != nil {
+		return err
+	}
+	c.store(key, value, meta, ttl, cost)
+	return nil
+}
+
+// checkEntry reports why an entry of cost bytes may not be stored under key,
+// if it may not. The caller must hold the write lock.
+func (c *LRUCache) checkEntry(key string, cost int64) error {
+	if c.maxKeyBytes > 0 && len(key) > c.maxKeyBytes {
+		return ErrKeyTooLong
+	}
+	if err := c.validateKey(key); err != nil {
+		c.rejected++
+		return err
+	}
+	if c.maxBytes > 0 && cost > c.maxBytes {
+		return ErrOversizedValue
+	}
+	return nil
+}
 
+// store inserts or updates an entry that passed checkEntry.
+// The caller must hold the write lock.
+func (c *LRUCache) store(key string, value string, meta map[string]string, ttl time.Duration, cost int64) {
 	// If the key already exists, update the value and move to head
 	if node, ok := c.Cache[key]; ok {
-		node.Value = value
+		c.bytes += cost - node.cost
+		c.release(node.Value)
+		node.Value = c.intern(value)
+		node.meta = meta
+		node.storedAt = c.now()
+		c.writes++
+		node.version = c.writes
+		if node.deleted {
+			// Reviving a soft-deleted key starts a new entry
+			node.deleted = false
+			node.accesses = 0
+		} else if !node.read {
+			c.overwrittenUnread++
+		}
+		node.read = false
+		node.cost = cost
+		node.expiresAt = c.expiry(ttl)
+		c.scheduleExpiry(node)
 		// Move the node to the head of the list
 		c.moveToHead(node)
+		c.auditOp("put", key, value)
+		c.replicatePut(node)
+		c.signalStored(key)
+		c.evictOverflow(0, 0)
 		return
 	}
 
 	// Create a new node
 	newNode := &Node{
-		Key:   key,
-		Value: value,
+		Key:        key,
+		Value:      c.intern(value),
+		meta:       meta,
+		accessedAt: c.now(),
+		storedAt:   c.now(),
+		expiresAt:  c.expiry(ttl),
+		cost:       cost,
 	}
+	c.writes++
+	newNode.version = c.writes
 
-	// If the cache is at capacity, remove the least recently used item
-	if len(c.Cache) >= c.Capacity {
-		tail := c.removeTail()
-		if tail != nil {
-			delete(c.Cache, tail.Key)
-		}
+	// If the cache is at capacity, remove the least recently used items
+	if c.reserve != nil {
+		c.evictUnreserved(c.reservedInsert(key))
 	}
-	
+	c.evictOverflow(1, cost)
+	c.auditOp("put", key, value)
+	c.replicatePut(newNode)
+
 	// Add the new node to the cache
 	c.Cache[key] = newNode
-	c.addToHead(newNode)
+	if c.index != nil {
+		c.index.insert(c.index.hash(key), newNode)
+	}
+	if c.reserve != nil {
+		c.reserve.add(key, 1)
+	}
+	c.scheduleExpiry(newNode)
+	c.bytes += cost
+	if c.probationaryFraction > 0 {
+		c.addToProbation(newNode)
+	} else {
+		c.addToHead(newNode)
+	}
+	c.signalStored(key)
+}
+
+// evictOverflow evicts from the tail until entries more items totalling cost
+// bytes fit within the capacity and the byte limit.
+func (c *LRUCache) evictOverflow(entries int, cost int64) {
+	for c.Tail != nil && c.overflows(entries, cost) {
+		victim := c.victim()
+		if victim == nil {
+			// Everything left is pinned
+			return
+		}
+		key := victim.Key
+		c.removeEntry(victim, ReasonCapacity)
+		c.recordEviction()
+		c.rememberGhost(key)
+	}
+}
+
+// victim picks the entry to evict: the most expensive of the last
+// lookback entries, which is simply the tail for the default of 1,
+// or the head for an MRU cache. With reserved slots it is the last entry
+// outside its reservation, if any. Pinned entries are skipped, and nil is
+// returned when all of them are.
+func (c *LRUCache) victim() *Node {
+	if c.reserve != nil {
+		if victim := c.unreservedVictim(); victim != nil {
+			return victim
+		}
+		return unpinnedFrom(c.Tail, false)
+	}
+	if c.evictMRU {
+		return unpinnedFrom(c.Head, true)
+	}
+	victim := unpinnedFrom(c.Tail, false)
+	if victim == nil {
+		return nil
+	}
+	node := unpinnedFrom(victim.Prev, false)
+	for i := 1; i < c.lookback && node != nil; i++ {
+		if node.cost > victim.cost {
+			victim = node
+		}
+		node = unpinnedFrom(node.Prev, false)
+	}
+	return victim
+}
+
+// overflows reports whether adding entries items totalling cost bytes
+// would exceed the capacity or the byte limit.
+func (c *LRUCache) overflows(entries int, cost int64) bool {
+	if c.Capacity != NoLimit && len(c.Cache)+entries > c.Capacity {
+		return true
+	}
+	return c.maxBytes > 0 && c.bytes+cost > c.maxBytes
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *LRUCache) Delete(key string) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok {
+		c.recordEvent("delete", key, "absent")
+		return false
+	}
+	c.removeEntry(node, ReasonDeleted)
+	c.recordEvent("delete", key, "deleted")
+	return true
+}
+
+// MoveToFront marks a key as the most recently used without reading it.
+// Returns false if the key is absent, expired or soft-deleted.
+func (c *LRUCache) MoveToFront(key string) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || !c.live(node) {
+		return false
+	}
+	c.moveToHead(node)
+	return true
+}
+
+// MoveToBack moves a key to the end of the list evictions start from, so it
+// is evicted next: the tail, or the head in an MRU cache. Its last access
+// time is left alone. Returns false if the key is absent, expired or
+// soft-deleted.
+func (c *LRUCache) MoveToBack(key string) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || !c.live(node) {
+		return false
+	}
+	if c.evictMRU {
+		accessedAt := node.accessedAt
+		c.moveToHead(node)
+		node.accessedAt = accessedAt
+		return true
+	}
+	if c.Tail == node {
+		return true
+	}
+
+	c.removeNode(node)
+	c.addToTail(node)
+	if c.probationaryFraction > 0 && c.probation == nil {
+		// The tail always belongs to the probationary segment
+		c.probation = node
+	}
+	return true
+}
+
+// Resize changes the capacity of the cache, evicting the least recently
+// used entries if it shrinks below the current size. Resizing an unbounded
+// cache gives it that capacity.
+func (c *LRUCache) Resize(capacity int) error {
+	if capacity <= 0 {
+		return ErrInvalidCapacity
+	}
+
+	c.mutex.Lock()
+	defer c.unlock()
+
+	c.resize(capacity)
+	return nil
+}
+
+// SimulateCapacity reports which live keys would survive a Resize to n and
+// which would be evicted, each most recently used first, without changing
+// anything. The split follows recency alone: byte limits, reservations and
+// the MRU policy are not taken into account.
+func (c *LRUCache) SimulateCapacity(n int) (survivors []string, evicted []string) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	survivors, evicted = []string{}, []string{}
+	for node := c.Head; node != nil; node = node.Next {
+		if !c.live(node) {
+			continue
+		}
+		if len(survivors) < n {
+			survivors = append(survivors, node.Key)
+		} else {
+			evicted = append(evicted, node.Key)
+		}
+	}
+	return survivors, evicted
+}
+
+// resize applies a new capacity. The caller must hold the write lock.
+func (c *LRUCache) resize(capacity int) {
+	c.Capacity = capacity
+	c.resizeSegments()
+	c.evictOverflow(0, 0)
+	c.evictUnreserved(0)
+	c.signalFreed()
 }
 
 // Clear removes all items from the cache.
 func (c *LRUCache) Clear() {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	defer c.unlock()
+
+	if c.notifyOnClear {
+		for node := c.Tail; node != nil; node = node.Prev {
+			c.notify(node, ReasonCleared)
+		}
+	}
+	c.reset()
+}
+
+// reset drops every entry. The caller must hold the write lock.
+func (c *LRUCache) reset() {
+	c.auditOp("clear", "", "")
+	if c.secureErase {
+		for node := c.Head; node != nil; {
+			next := node.Next
+			c.erase(node)
+			node = next
+		}
+	}
 
 	c.Head = nil
 	c.Tail = nil
 	c.Cache = make(map[string]*Node)
+	if c.index != nil {
+		c.index.reset()
+	}
+	c.probation = nil
+	c.protectedLen = 0
+	c.bytes = 0
+	if c.reserve != nil {
+		clear(c.reserve.used)
+	}
+	if c.reaper != nil {
+		c.reaper.heap = nil
+	}
+	c.signalFreed()
+	if c.interned != nil {
+		c.interned = make(map[string]*internedValue)
+	}
 }
 
-// Size returns the current number of items in the cache.
+// Size returns the current number of items in the cache,
+// including soft-deleted entries that were not compacted yet.
 func (c *LRUCache) Size() int {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 	return len(c.Cache)
 }
 
+// Utilization returns how full the cache is, Size divided by the current
+// capacity, from 0 for empty to 1 for full. It exceeds 1 while pinned entries
+// keep the cache over capacity, and is always 0 for an unbounded cache.
+func (c *LRUCache) Utilization() float64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.Capacity == NoLimit {
+		return 0
+	}
+	return float64(len(c.Cache)) / float64(c.Capacity)
+}
+
 // IsEmpty checks if the cache is empty.
 func (c *LRUCache) IsEmpty() bool {
 	c.mutex.RLock()
@@ -164,9 +758,31 @@ func (c *LRUCache) IsEmpty() bool {
 
 // Contains checks if the cache contains a specific key.
 func (c *LRUCache) Has(key string) bool {
+	key = c.normalizeKey(key)
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
-	_, ok := c.Cache[key]
-	return ok
+	node, ok := c.Cache[key]
+	return ok && c.live(node)
 }
 
+// HasMulti reports for each key whether it is present, like Has, under a
+// single read lock. The result is keyed by the keys as passed in, before
+// normalization; expired entries count as absent.
+func (c *LRUCache) HasMulti(keys []string) map[string]bool {
+	present := make(map[string]bool, len(keys))
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, key := range keys {
+		node, ok := c.Cache[c.normalizeKey(key)]
+		present[key] = ok && c.live(node)
+	}
+	return present
+}
+
+// normalizeKey applies the configured key normalizer, if any.
+func (c *LRUCache) normalizeKey(key string) string {
+	if c.normalize == nil {
+		return key
+	}
+	return c.normalize(key)
+}
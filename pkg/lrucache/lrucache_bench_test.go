@@ -0,0 +1,167 @@
+package lrucache
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// benchSizes are the cache capacities every benchmark runs at.
+var benchSizes = []int{100, 10_000, 1_000_000}
+
+// benchKeys returns n distinct keys.
+func benchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// filledCache returns a cache of the given capacity holding keys.
+func filledCache(b *testing.B, capacity int, keys []string) *LRUCache {
+	b.Helper()
+	c, err := NewLRUCache(capacity)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, key := range keys {
+		c.Put(key, key)
+	}
+	return c
+}
+
+// runSizes runs bench as a sub-benchmark per cache size.
+func runSizes(b *testing.B, bench func(b *testing.B, size int)) {
+	for _, size := range benchSizes {
+		b.Run("size="+strconv.Itoa(size), func(b *testing.B) {
+			b.ReportAllocs()
+			bench(b, size)
+		})
+	}
+}
+
+// BenchmarkGet looks up a mix of present and absent keys.
+func BenchmarkGet(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(2 * size)
+		c := filledCache(b, size, keys[:size])
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.Get(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkGetHit(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(size)
+		c := filledCache(b, size, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.Get(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkGetMiss(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(2 * size)
+		c := filledCache(b, size, keys[:size])
+		missing := keys[size:]
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.Get(missing[i%len(missing)])
+		}
+	})
+}
+
+// BenchmarkPut inserts new keys into a full cache, evicting on every Put.
+func BenchmarkPut(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(2 * size)
+		c := filledCache(b, size, keys[:size])
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			key := keys[(size+i)%len(keys)]
+			c.Put(key, key)
+		}
+	})
+}
+
+// BenchmarkDelete deletes and puts back keys, so every Delete hits.
+func BenchmarkDelete(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(size)
+		c := filledCache(b, size, keys)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			key := keys[i%len(keys)]
+			c.Delete(key)
+			b.StopTimer()
+			c.Put(key, key)
+			b.StartTimer()
+		}
+	})
+}
+
+// BenchmarkConcurrentGet runs Gets from GOMAXPROCS goroutines.
+func BenchmarkConcurrentGet(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(size)
+		c := filledCache(b, size, keys)
+		var next atomic.Uint64
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)) * 7919
+			for pb.Next() {
+				c.Get(keys[i%len(keys)])
+				i++
+			}
+		})
+	})
+}
+
+// BenchmarkConcurrentPut runs Puts from GOMAXPROCS goroutines.
+func BenchmarkConcurrentPut(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(2 * size)
+		c := filledCache(b, size, keys[:size])
+		var next atomic.Uint64
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)) * 7919
+			for pb.Next() {
+				key := keys[i%len(keys)]
+				c.Put(key, key)
+				i++
+			}
+		})
+	})
+}
+
+// BenchmarkMixed runs 80% Gets, 15% Puts and 5% Deletes from GOMAXPROCS
+// goroutines over twice as many keys as the cache holds.
+func BenchmarkMixed(b *testing.B) {
+	runSizes(b, func(b *testing.B, size int) {
+		keys := benchKeys(2 * size)
+		c := filledCache(b, size, keys[:size])
+		var next atomic.Uint64
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)) * 7919
+			for pb.Next() {
+				key := keys[i%len(keys)]
+				switch op := i % 20; {
+				case op < 16:
+					c.Get(key)
+				case op < 19:
+					c.Put(key, key)
+				default:
+					c.Delete(key)
+				}
+				i++
+			}
+		})
+	})
+}
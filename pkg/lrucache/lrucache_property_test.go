@@ -0,0 +1,82 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// TestPropertySize checks that N Puts of distinct keys into a capacity-K
+// cache leave exactly min(N, K) entries.
+func TestPropertySize(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(1, 32).Draw(t, "capacity")
+		n := rapid.IntRange(0, 100).Draw(t, "n")
+
+		c, err := NewLRUCache(capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < n; i++ {
+			c.Put(strconv.Itoa(i), "v")
+		}
+
+		if got, want := c.Size(), min(n, capacity); got != want {
+			t.Fatalf("Size() = %d after %d distinct Puts into capacity %d, want %d", got, n, capacity, want)
+		}
+		checkIntegrity(t, c)
+	})
+}
+
+// TestPropertyLRU runs random operation sequences and checks after each step
+// that a Get moves its key to the head and that a Put overflowing the
+// capacity evicts the key that was at the tail.
+func TestPropertyLRU(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(1, 8).Draw(t, "capacity")
+		c, err := NewLRUCache(capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := rapid.StringMatching(`[a-l]`)
+
+		t.Repeat(map[string]func(*rapid.T){
+			"Get": func(t *rapid.T) {
+				k := key.Draw(t, "key")
+				if _, ok := c.Get(k); ok && c.Head.Key != k {
+					t.Fatalf("Get(%q) hit but the head is %q", k, c.Head.Key)
+				}
+			},
+			"Put": func(t *rapid.T) {
+				k := key.Draw(t, "key")
+				var tail string
+				overflow := !c.Has(k) && c.Size() == capacity
+				if overflow {
+					tail = c.Tail.Key
+				}
+
+				c.Put(k, k)
+				if c.Head.Key != k {
+					t.Fatalf("Put(%q) left %q at the head", k, c.Head.Key)
+				}
+				if overflow && c.Has(tail) {
+					t.Fatalf("Put(%q) overflowed the capacity but the tail %q survived", k, tail)
+				}
+			},
+			"Delete": func(t *rapid.T) {
+				k := key.Draw(t, "key")
+				had := c.Has(k)
+				if c.Delete(k) != had || c.Has(k) {
+					t.Fatalf("Delete(%q) disagrees with Has", k)
+				}
+			},
+			"": func(t *rapid.T) {
+				if c.Size() > capacity {
+					t.Fatalf("Size() = %d exceeds the capacity %d", c.Size(), capacity)
+				}
+				checkIntegrity(t, c)
+			},
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package lrucache
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHasMulti(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(4,
+		WithClock(func() time.Time { return now }),
+		WithKeyNormalizer(strings.ToLower),
+	)
+	c.Put("present", "v")
+	c.PutWithTTL("expired", "v", time.Minute)
+	now = now.Add(time.Hour)
+
+	got := c.HasMulti([]string{"present", "PRESENT", "absent", "expired"})
+	want := map[string]bool{"present": true, "PRESENT": true, "absent": false, "expired": false}
+	if !maps.Equal(got, want) {
+		t.Fatalf("HasMulti = %v, want %v", got, want)
+	}
+	if got := c.HasMulti(nil); len(got) != 0 {
+		t.Fatalf("HasMulti(nil) = %v, want empty", got)
+	}
+}
+
+func TestTryPut(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithMaxBytes(32))
+	if !c.TryPut("a", "1") || !c.Has("a") {
+		t.Fatal("TryPut of a new entry = false")
+	}
+	if !c.TryPut("a", "2") {
+		t.Fatal("TryPut of an update = false")
+	}
+	if c.TryPut("big", strings.Repeat("v", 64)) || c.Has("big") {
+		t.Fatal("TryPut of an oversized entry = true")
+	}
+
+	v, _ := NewLRUCacheWithOptions(2, WithKeyValidator(func(key string) error {
+		if strings.Contains(key, " ") {
+			return errors.New("space in key")
+		}
+		return nil
+	}))
+	if v.TryPut("bad key", "1") || v.Has("bad key") {
+		t.Fatal("TryPut of a key the validator rejects = true")
+	}
+	// An insert that evicts another entry still succeeds
+	c.TryPut("b", "1")
+	if !c.TryPut("c", "1") || c.Has("a") {
+		t.Fatalf("TryPut into a full cache did not evict; keys = %v", listKeys(c))
+	}
+}
+
+func TestTryPutWhenEvictionIsRefused(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithOnFull(ErrorPolicy))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	if c.TryPut("c", "3") || c.Has("c") {
+		t.Fatal("TryPut into a full cache under ErrorPolicy = true")
+	}
+	if !c.TryPut("a", "updated") {
+		t.Fatal("TryPut of an update under ErrorPolicy = false")
+	}
+	if got := listKeys(c); len(got) != 2 {
+		t.Fatalf("keys = %v, want a and b kept", got)
+	}
+}
+
+func TestMRUEviction(t *testing.T) {
+	var r recorder
+	c, _ := NewMRUCache(3, WithOnEvict(r.onEvict))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	c.Get("a") // a is now the most recently used
+
+	c.Put("d", "4")
+	if got := listKeys(c); !slices.Equal(got, []string{"d", "c", "b"}) {
+		t.Fatalf("keys = %v, want the head a evicted and the tail b kept", got)
+	}
+	c.Put("e", "5")
+	if c.Has("d") || !c.Has("b") {
+		t.Fatalf("keys = %v, want d evicted as the newest entry", listKeys(c))
+	}
+	if got := r.got(); len(got) != 2 || got[0].key != "a" || got[1].key != "d" {
+		t.Fatalf("evictions = %v, want a then d", got)
+	}
+	checkIntegrity(t, c)
+}
+
+func TestSimulateCapacity(t *testing.T) {
+	c, _ := NewLRUCache(5)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		c.Put(key, "v")
+	}
+	c.Get("b") // order: b e d c a
+	c.SoftDelete("d")
+	before := listKeys(c)
+
+	survivors, evicted := c.SimulateCapacity(2)
+	if !slices.Equal(survivors, []string{"b", "e"}) || !slices.Equal(evicted, []string{"c", "a"}) {
+		t.Fatalf("SimulateCapacity(2) = %v, %v, want [b e], [c a]", survivors, evicted)
+	}
+	if got := listKeys(c); !slices.Equal(got, before) {
+		t.Fatalf("keys = %v after the simulation, want %v unchanged", got, before)
+	}
+
+	// The simulation matches what Resize then does.
+	c.Resize(2)
+	if got := listKeys(c); !slices.Equal(got, survivors) {
+		t.Fatalf("keys after Resize(2) = %v, want %v", got, survivors)
+	}
+
+	survivors, evicted = c.SimulateCapacity(10)
+	if len(survivors) != 2 || len(evicted) != 0 {
+		t.Fatalf("SimulateCapacity(10) = %v, %v, want everything kept", survivors, evicted)
+	}
+	if survivors, evicted = c.SimulateCapacity(0); len(survivors) != 0 || len(evicted) != 2 {
+		t.Fatalf("SimulateCapacity(0) = %v, %v, want everything evicted", survivors, evicted)
+	}
+}
+
+func TestUtilization(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	want := func(ratio float64) {
+		t.Helper()
+		if got := c.Utilization(); got != ratio {
+			t.Fatalf("Utilization = %v, want %v", got, ratio)
+		}
+	}
+	want(0)
+	c.Put("a", "1")
+	c.Put("b", "2")
+	want(0.5)
+	c.Put("c", "3")
+	c.Put("d", "4")
+	want(1)
+
+	c.Resize(8)
+	want(0.5)
+	c.Resize(2)
+	want(1)
+
+	// Pinned entries can hold the cache over capacity.
+	c.Pin("c")
+	c.Pin("d")
+	c.Put("e", "5")
+	want(1.5)
+
+	u := NewUnboundedLRUCache()
+	u.Put("a", "1")
+	if got := u.Utilization(); got != 0 {
+		t.Fatalf("Utilization of an unbounded cache = %v, want 0", got)
+	}
+}
@@ -0,0 +1,264 @@
+// Package lrucachetest provides a conformance suite for implementations of
+// lrucache.Cache, so that alternative caches can be checked against the same
+// contract as LRUCache from their own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+//			c, _ := lrucache.NewLRUCache(capacity)
+//			return c
+//		})
+//	}
+package lrucachetest
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+)
+
+// TTLCache is implemented by caches supporting per-entry expiry.
+// RunConformance checks the TTL contract only for those.
+type TTLCache interface {
+	PutWithTTL(key, value string, ttl time.Duration) error
+}
+
+// EvictionNotifier is implemented by caches reporting removed entries.
+// RunConformance checks the callback contract only for those.
+type EvictionNotifier interface {
+	SetOnEvict(fn func(key, value string, reason lrucache.EvictionReason))
+}
+
+// RunConformance runs the Cache contract against fresh caches built by factory,
+// each as a subtest: strict LRU eviction order, update semantics, Delete and
+// Clear, capacity-1 edge cases and concurrent access (meaningful under -race).
+// TTL and eviction callbacks are checked when the cache implements TTLCache or
+// EvictionNotifier.
+func RunConformance(t *testing.T, factory func(capacity int) lrucache.Cache) {
+	t.Run("GetPut", func(t *testing.T) { testGetPut(t, factory) })
+	t.Run("EvictionOrder", func(t *testing.T) { testEvictionOrder(t, factory) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, factory) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, factory) })
+	t.Run("Clear", func(t *testing.T) { testClear(t, factory) })
+	t.Run("CapacityOne", func(t *testing.T) { testCapacityOne(t, factory) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, factory) })
+	t.Run("TTL", func(t *testing.T) { testTTL(t, factory) })
+	t.Run("OnEvict", func(t *testing.T) { testOnEvict(t, factory) })
+}
+
+func testGetPut(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(2)
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get on an empty cache reported a hit")
+	}
+
+	c.Put("a", "1")
+	wantValue(t, c, "a", "1")
+	if !c.Has("a") || c.Has("b") {
+		t.Fatal("Has disagrees with the stored keys")
+	}
+	if got := c.Size(); got != 1 {
+		t.Fatalf("Size() = %d, want 1", got)
+	}
+}
+
+func testEvictionOrder(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(3)
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	wantValue(t, c, "a", "1") // a becomes the most recently used
+
+	c.Put("d", "4")
+	if c.Has("b") {
+		t.Fatal("least recently used key b survived an eviction")
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if !c.Has(key) {
+			t.Fatalf("key %q was evicted instead of b", key)
+		}
+	}
+	if got := c.Size(); got != 3 {
+		t.Fatalf("Size() = %d, want 3", got)
+	}
+}
+
+func testUpdate(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(2)
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("a", "updated") // a becomes the most recently used
+
+	if got := c.Size(); got != 2 {
+		t.Fatalf("Size() = %d after an update, want 2", got)
+	}
+	wantValue(t, c, "a", "updated")
+
+	c.Put("c", "3")
+	if c.Has("b") || !c.Has("a") {
+		t.Fatal("an update did not make the key most recently used")
+	}
+}
+
+func testDelete(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(2)
+	c.Put("a", "1")
+
+	if !c.Delete("a") {
+		t.Fatal("Delete of a present key returned false")
+	}
+	if c.Delete("a") {
+		t.Fatal("Delete of a missing key returned true")
+	}
+	if c.Has("a") || c.Size() != 0 {
+		t.Fatal("deleted key is still cached")
+	}
+
+	// The freed slot is usable again
+	c.Put("b", "2")
+	c.Put("c", "3")
+	if !c.Has("b") || !c.Has("c") {
+		t.Fatal("cache lost entries after a Delete freed a slot")
+	}
+}
+
+func testClear(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(3)
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Clear()
+
+	if got := c.Size(); got != 0 {
+		t.Fatalf("Size() = %d after Clear, want 0", got)
+	}
+	if c.Has("a") || c.Has("b") {
+		t.Fatal("keys survived Clear")
+	}
+
+	c.Put("c", "3")
+	wantValue(t, c, "c", "3")
+}
+
+func testCapacityOne(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(1)
+	c.Put("a", "1")
+	c.Put("a", "2")
+	wantValue(t, c, "a", "2")
+
+	c.Put("b", "3")
+	if c.Has("a") {
+		t.Fatal("capacity 1 cache kept two keys")
+	}
+	wantValue(t, c, "b", "3")
+	if got := c.Size(); got != 1 {
+		t.Fatalf("Size() = %d, want 1", got)
+	}
+}
+
+func testConcurrent(t *testing.T, factory func(int) lrucache.Cache) {
+	const capacity = 64
+	c := factory(capacity)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa((g*31 + i) % (capacity * 2))
+				switch i % 4 {
+				case 0, 1:
+					c.Put(key, key)
+				case 2:
+					if value, ok := c.Get(key); ok && value != key {
+						t.Errorf("Get(%q) = %q, want %q", key, value, key)
+					}
+				case 3:
+					c.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if got := c.Size(); got > capacity {
+		t.Fatalf("Size() = %d exceeds the capacity %d", got, capacity)
+	}
+}
+
+func testTTL(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(2)
+	ttlCache, ok := c.(TTLCache)
+	if !ok {
+		t.Skip("cache does not implement TTLCache")
+	}
+
+	if err := ttlCache.PutWithTTL("short", "1", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := ttlCache.PutWithTTL("forever", "2", 0); err != nil {
+		t.Fatal(err)
+	}
+	wantValue(t, c, "short", "1")
+
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Fatal("expired entry was returned")
+	}
+	if c.Has("short") {
+		t.Fatal("Has reported an expired entry")
+	}
+	wantValue(t, c, "forever", "2")
+}
+
+func testOnEvict(t *testing.T, factory func(int) lrucache.Cache) {
+	c := factory(1)
+	notifier, ok := c.(EvictionNotifier)
+	if !ok {
+		t.Skip("cache does not implement EvictionNotifier")
+	}
+
+	type evicted struct {
+		key, value string
+		reason     lrucache.EvictionReason
+	}
+	var mutex sync.Mutex
+	var got []evicted
+	notifier.SetOnEvict(func(key, value string, reason lrucache.EvictionReason) {
+		// Callbacks may call back into the cache
+		c.Has(key)
+
+		mutex.Lock()
+		got = append(got, evicted{key, value, reason})
+		mutex.Unlock()
+	})
+
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Delete("b")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	want := []evicted{
+		{"a", "1", lrucache.ReasonCapacity},
+		{"b", "2", lrucache.ReasonDeleted},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d callbacks %v, want %v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("callback %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// wantValue fails the test unless Get(key) returns value.
+func wantValue(t *testing.T, c lrucache.Cache, key, value string) {
+	t.Helper()
+	if got, ok := c.Get(key); !ok || got != value {
+		t.Fatalf("Get(%q) = %q, %v, want %q, true", key, got, ok, value)
+	}
+}
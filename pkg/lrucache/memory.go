@@ -0,0 +1,27 @@
+package lrucache
+
+import "unsafe"
+
+// entryOverhead estimates the fixed bytes one entry costs besides its key,
+// value and metadata bytes: the Node itself plus its share of the map, i.e. a
+// key string header and a pointer in a bucket slot with the bucket's tophash
+// byte and load factor slack rounded up to the same again.
+const entryOverhead = int64(unsafe.Sizeof(Node{})) +
+	2*int64(unsafe.Sizeof("")+unsafe.Sizeof((*Node)(nil))+1)
+
+// MemoryUsage returns an estimate of the memory held by the entries in bytes:
+// key, value and metadata lengths plus a fixed per-entry overhead for the list
+// node and map slot. It is maintained incrementally, so it is cheap to poll.
+// It is only an estimate: allocator size classes and shared backing arrays,
+// e.g. with WithValueInterning, are not taken into account.
+func (c *LRUCache) MemoryUsage() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.memoryUsage()
+}
+
+// memoryUsage computes the estimate. The caller must hold at least the read lock.
+func (c *LRUCache) memoryUsage() int64 {
+	return c.bytes + int64(len(c.Cache))*entryOverhead
+}
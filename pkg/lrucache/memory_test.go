@@ -0,0 +1,121 @@
+package lrucache
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// heapInUse returns the live heap after a full collection.
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestMemoryUsageEstimate(t *testing.T) {
+	for _, valueSize := range []int{8, 100, 1000} {
+		t.Run(strconv.Itoa(valueSize), func(t *testing.T) {
+			const entries = 20_000
+			keys := make([]string, entries)
+			for i := range keys {
+				keys[i] = "key-" + strconv.Itoa(i)
+			}
+			value := strings.Repeat("v", valueSize)
+
+			before := heapInUse()
+			c, _ := NewLRUCache(entries)
+			for _, key := range keys {
+				// A fresh copy per entry, as values read from the network would be
+				c.Put(key, strings.Clone(value))
+			}
+			measured := float64(heapInUse() - before)
+
+			// Keys were allocated before the measurement, so add them back.
+			for _, key := range keys {
+				measured += float64(len(key))
+			}
+			estimate := float64(c.MemoryUsage())
+			runtime.KeepAlive(c)
+
+			if ratio := estimate / measured; ratio < 0.5 || ratio > 2 {
+				t.Fatalf("MemoryUsage() = %.0f, measured %.0f bytes, ratio %.2f outside [0.5, 2]", estimate, measured, ratio)
+			}
+			if got := c.Stats().MemoryUsage; float64(got) != estimate {
+				t.Fatalf("Stats().MemoryUsage = %d, want %.0f", got, estimate)
+			}
+		})
+	}
+}
+
+func TestMemoryUsageTracksRemovals(t *testing.T) {
+	c, _ := NewLRUCache(2)
+	c.Put("a", "12345")
+	one := c.MemoryUsage()
+	if want := int64(len("a")+len("12345")) + entryOverhead; one != want {
+		t.Fatalf("MemoryUsage() = %d, want %d", one, want)
+	}
+	c.Put("b", "12345")
+	c.Put("c", "12345") // evicts a
+	if got := c.MemoryUsage(); got != 2*one {
+		t.Fatalf("MemoryUsage() = %d after an eviction, want %d", got, 2*one)
+	}
+	c.Delete("b")
+	c.Put("c", "1234567")
+	if got := c.MemoryUsage(); got != one+2 {
+		t.Fatalf("MemoryUsage() = %d after a delete and update, want %d", got, one+2)
+	}
+	c.Clear()
+	if got := c.MemoryUsage(); got != 0 {
+		t.Fatalf("MemoryUsage() = %d after Clear, want 0", got)
+	}
+}
+
+func TestLongKeysCountTowardByteLimit(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithMaxBytes(100))
+	long := strings.Repeat("k", 60)
+	c.Put(long, "v")
+	if c.bytes != 61 {
+		t.Fatalf("bytes = %d, want 61 for a 60-byte key and 1-byte value", c.bytes)
+	}
+
+	// Both values are tiny, but the keys together pass the byte limit.
+	c.Put(strings.Repeat("j", 60), "v")
+	if c.Has(long) {
+		t.Fatal("the first long key survived a Put over the byte limit")
+	}
+	if err := c.PutE(strings.Repeat("x", 100), "v"); !errors.Is(err, ErrOversizedValue) {
+		t.Fatalf("PutE with a key over the byte limit error = %v, want ErrOversizedValue", err)
+	}
+
+	if !c.Rename(strings.Repeat("j", 60), "short") {
+		t.Fatal("Rename = false")
+	}
+	if c.bytes != 6 {
+		t.Fatalf("bytes after Rename = %d, want 6", c.bytes)
+	}
+	c.Delete("short")
+	if c.bytes != 0 || c.MemoryUsage() != 0 {
+		t.Fatalf("bytes = %d, MemoryUsage = %d after deleting everything", c.bytes, c.MemoryUsage())
+	}
+}
+
+func TestMaxKeyBytes(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithMaxKeyBytes(8), WithKeyNormalizer(strings.TrimSpace))
+	if err := c.PutE("12345678", "v"); err != nil {
+		t.Fatalf("PutE at the key limit error = %v", err)
+	}
+	if err := c.PutE("123456789", "v"); !errors.Is(err, ErrKeyTooLong) {
+		t.Fatalf("PutE over the key limit error = %v, want ErrKeyTooLong", err)
+	}
+	// The limit applies after normalization.
+	if err := c.PutE("  abcdefgh  ", "v"); err != nil {
+		t.Fatalf("PutE of a key that normalizes within the limit error = %v", err)
+	}
+	if c.Size() != 2 {
+		t.Fatalf("Size = %d, want 2", c.Size())
+	}
+}
@@ -0,0 +1,49 @@
+package lrucache
+
+// PutWithMeta adds a key-value pair together with caller-defined metadata,
+// such as an ETag or the source the value came from. The metadata is copied,
+// replaced by every later write to the key and counted toward the byte limit.
+// A plain Put drops any metadata the key had.
+func (c *LRUCache) PutWithMeta(key, value string, meta map[string]string) error {
+	return c.set(c.normalizeKey(key), value, meta, c.ttl)
+}
+
+// Meta returns a copy of the metadata stored with key, without promoting it.
+// The map is nil if the entry was stored without metadata.
+func (c *LRUCache) Meta(key string) (map[string]string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	node, ok := c.Cache[c.normalizeKey(key)]
+	if !ok || !c.live(node) {
+		return nil, false
+	}
+	return copyMeta(node.meta), true
+}
+
+// copyMeta returns a private copy of meta, or nil if it is empty.
+func copyMeta(meta map[string]string) map[string]string {
+	if len(meta) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(meta))
+	for k, v := range meta {
+		copied[k] = v
+	}
+	return copied
+}
+
+// entryCost returns the bytes an entry is charged against the byte limit:
+// its key, value and metadata lengths.
+func entryCost(key, value string, meta map[string]string) int64 {
+	return int64(len(key)+len(value)) + metaCost(meta)
+}
+
+// metaCost returns the bytes metadata is charged against the byte limit.
+func metaCost(meta map[string]string) int64 {
+	var cost int64
+	for k, v := range meta {
+		cost += int64(len(k) + len(v))
+	}
+	return cost
+}
@@ -0,0 +1,35 @@
+package lrucache
+
+// MetricsSnapshot returns the cache statistics as named numbers, to feed any
+// metrics system without the package depending on one. Counters are totals
+// since creation and hit_rate is a ratio between 0 and 1:
+//
+//	size, capacity, memory_bytes, hits, misses, hit_rate, evictions,
+//	rejected, slow_loads, stale_served, victim_hits, evicted_unread,
+//	overwritten_unread, unread_ratio, audit_dropped
+func (c *LRUCache) MetricsSnapshot() map[string]float64 {
+	stats := c.Stats()
+
+	metrics := map[string]float64{
+		"size":         float64(stats.Size),
+		"capacity":     float64(stats.Capacity),
+		"memory_bytes": float64(stats.MemoryUsage),
+		"hits":         float64(stats.Hits),
+		"misses":       float64(stats.Misses),
+		"hit_rate":     0,
+		"evictions":    float64(stats.Evictions),
+		"rejected":     float64(stats.Rejected),
+		"slow_loads":   float64(stats.SlowLoads),
+		"stale_served": float64(stats.StaleServed),
+		"victim_hits":  float64(stats.VictimHits),
+
+		"evicted_unread":     float64(stats.EvictedUnread),
+		"overwritten_unread": float64(stats.OverwrittenUnread),
+		"unread_ratio":       stats.UnreadRatio,
+		"audit_dropped":      float64(stats.AuditDropped),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		metrics["hit_rate"] = float64(stats.Hits) / float64(total)
+	}
+	return metrics
+}
@@ -0,0 +1,54 @@
+package lrucache
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestMetricsSnapshot(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithKeyValidator(func(key string) error {
+		if key == "bad" {
+			return errors.New("bad key")
+		}
+		return nil
+	}))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("a", "3") // overwrites an unread value
+	c.Get("b")
+	c.Put("c", "4") // evicts a, still unread
+	c.Get("x")
+	c.Put("bad", "5")
+
+	got := c.MetricsSnapshot()
+	want := map[string]float64{
+		"size":               2,
+		"capacity":           2,
+		"memory_bytes":       float64(c.MemoryUsage()),
+		"hits":               1,
+		"misses":             1,
+		"hit_rate":           0.5,
+		"evictions":          1,
+		"rejected":           1,
+		"slow_loads":         0,
+		"stale_served":       0,
+		"victim_hits":        0,
+		"evicted_unread":     1,
+		"overwritten_unread": 1,
+		"unread_ratio":       0.5,
+		"audit_dropped":      0,
+	}
+	if !maps.Equal(got, want) {
+		keys := slices.Sorted(maps.Keys(got))
+		t.Fatalf("MetricsSnapshot keys %v\ngot  %v\nwant %v", keys, got, want)
+	}
+}
+
+func TestMetricsSnapshotEmpty(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	if rate := c.MetricsSnapshot()["hit_rate"]; rate != 0 {
+		t.Fatalf("hit_rate = %v before any lookup, want 0", rate)
+	}
+}
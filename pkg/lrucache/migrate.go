@@ -0,0 +1,65 @@
+package lrucache
+
+import "errors"
+
+// CacheReader is a cache whose entries can be taken out oldest first.
+type CacheReader interface {
+	// OldestEntries returns up to n entries, least recently used first,
+	// without promoting them.
+	OldestEntries(n int) []Entry
+	// CompareAndDelete removes key only if it still holds value.
+	CompareAndDelete(key, value string) bool
+}
+
+// CacheWriter is a cache that reports whether it accepted an entry.
+type CacheWriter interface {
+	PutE(key string, value string) error
+}
+
+var (
+	_ CacheReader = (*LRUCache)(nil)
+	_ CacheWriter = (*LRUCache)(nil)
+)
+
+// Migrate moves every entry from src to dst, least recently used first, in
+// batches of batchSize, and returns the number of entries moved. Entries are
+// only deleted from src once dst accepted them, so both caches keep serving
+// during the migration and the most recently used entries end up the most
+// recent in dst. A dst smaller than src evicts the oldest migrated entries as
+// usual. An entry overwritten in src after it was copied is left there, and a
+// later batch copies its new value. Migrate stops at the first entry dst rejects and returns its error,
+// leaving that entry and the ones not yet moved in src.
+func Migrate(src CacheReader, dst CacheWriter, batchSize int) (moved int, err error) {
+	if batchSize <= 0 {
+		return 0, errors.New("invalid batch size: must be greater than 0")
+	}
+
+	for {
+		batch := src.OldestEntries(batchSize)
+		if len(batch) == 0 {
+			return moved, nil
+		}
+		for _, entry := range batch {
+			if err := dst.PutE(entry.Key, entry.Value); err != nil {
+				return moved, err
+			}
+			src.CompareAndDelete(entry.Key, entry.Value)
+			moved++
+		}
+	}
+}
+
+// OldestEntries returns up to n live entries, least recently used first,
+// without promoting them.
+func (c *LRUCache) OldestEntries(n int) []Entry {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entries := make([]Entry, 0, min(n, len(c.Cache)))
+	for node := c.Tail; node != nil && len(entries) < n; node = node.Prev {
+		if c.live(node) {
+			entries = append(entries, node.entry())
+		}
+	}
+	return entries
+}
@@ -0,0 +1,87 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+)
+
+// racingWriter overwrites each key in src right after dst accepted it, the
+// way a concurrent Put landing between the copy and the delete would.
+type racingWriter struct {
+	dst, src *LRUCache
+	written  map[string]bool
+}
+
+func (w *racingWriter) PutE(key, value string) error {
+	if err := w.dst.PutE(key, value); err != nil {
+		return err
+	}
+	if !w.written[key] {
+		w.written[key] = true
+		w.src.Put(key, "new-"+key)
+	}
+	return nil
+}
+
+func TestMigrateKeepsConcurrentWrites(t *testing.T) {
+	src, _ := NewLRUCache(10)
+	dst, _ := NewLRUCache(10)
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		src.Put(key, "old-"+key)
+	}
+
+	moved, err := Migrate(src, &racingWriter{dst: dst, src: src, written: make(map[string]bool)}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 20 {
+		t.Fatalf("moved = %d, want 20", moved)
+	}
+	if src.Size() != 0 {
+		t.Fatalf("src still holds %v", listKeys(src))
+	}
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		if got, _ := dst.Get(key); got != "new-"+key {
+			t.Errorf("dst[%s] = %q, want %q", key, got, "new-"+key)
+		}
+	}
+}
+
+// Run with -race: a writer keeps updating src while Migrate drains it.
+func TestMigrateWithConcurrentWriter(t *testing.T) {
+	const keys = 100
+	src, _ := NewLRUCache(keys)
+	dst, _ := NewLRUCache(keys)
+	for i := 0; i < keys; i++ {
+		src.Put(strconv.Itoa(i), "0")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for round := 1; round <= 5; round++ {
+			for i := 0; i < keys; i++ {
+				src.Put(strconv.Itoa(i), strconv.Itoa(round))
+			}
+		}
+	}()
+	if _, err := Migrate(src, dst, 7); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+
+	// Writes that landed after the first pass are picked up by a second one.
+	if _, err := Migrate(src, dst, 7); err != nil {
+		t.Fatal(err)
+	}
+	if src.Size() != 0 {
+		t.Fatalf("src still holds %d entries", src.Size())
+	}
+	for i := 0; i < keys; i++ {
+		if got, _ := dst.Get(strconv.Itoa(i)); got != "5" {
+			t.Errorf("dst[%d] = %q, want 5", i, got)
+		}
+	}
+}
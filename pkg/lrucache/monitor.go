@@ -0,0 +1,80 @@
+package lrucache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// hitRateDrop is the relative drop between two samples that raises an alert.
+const hitRateDrop = 0.2
+
+// HitRateMonitor samples the hit rate of a cache periodically and raises an
+// alert when it drops suddenly, a sign of an invalidation storm, a change in
+// the key pattern or a cache that became too small.
+type HitRateMonitor struct {
+	cache   *LRUCache
+	alertFn func(prev, current float64)
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewHitRateMonitor starts sampling the hit rate of cache every sampleInterval
+// and calls alertFn when it falls by more than 20% relative to the previous
+// sample. Each sample is the hit rate, in percent, of the lookups made during
+// the interval; intervals without lookups are skipped. alertFn runs on the
+// monitor goroutine. Call Stop to end the monitoring.
+// A sampleInterval of zero or less is rejected with an error.
+func NewHitRateMonitor(cache *LRUCache, sampleInterval time.Duration, alertFn func(prev, current float64)) (*HitRateMonitor, error) {
+	if sampleInterval <= 0 {
+		return nil, errors.New("invalid sample interval: must be greater than 0")
+	}
+
+	m := &HitRateMonitor{
+		cache:   cache,
+		alertFn: alertFn,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go m.run(sampleInterval)
+	return m, nil
+}
+
+// Stop ends the monitoring and waits for the goroutine to exit.
+// It is safe to call more than once.
+func (m *HitRateMonitor) Stop() {
+	m.once.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+func (m *HitRateMonitor) run(interval time.Duration) {
+	defer close(m.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	stats := m.cache.Stats()
+	hits, misses := stats.Hits, stats.Misses
+	prev, sampled := 0.0, false
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		stats := m.cache.Stats()
+		lookups := stats.Hits - hits + stats.Misses - misses
+		if lookups == 0 {
+			continue
+		}
+		current := float64(stats.Hits-hits) / float64(lookups) * 100
+		hits, misses = stats.Hits, stats.Misses
+
+		if sampled && current < prev*(1-hitRateDrop) && m.alertFn != nil {
+			m.alertFn(prev, current)
+		}
+		prev, sampled = current, true
+	}
+}
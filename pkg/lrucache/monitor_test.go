@@ -0,0 +1,53 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHitRateMonitorRejectsInterval(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if m, err := NewHitRateMonitor(c, interval, nil); err == nil || m != nil {
+			t.Fatalf("NewHitRateMonitor(%v) = %v, %v, want an error", interval, m, err)
+		}
+	}
+}
+
+func TestHitRateMonitorAlertsOnDrop(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("hot", "v")
+	alerts := make(chan [2]float64, 10)
+	m, err := NewHitRateMonitor(c, 20*time.Millisecond, func(prev, current float64) {
+		alerts <- [2]float64{prev, current}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+
+	// Keep hitting until a sample is taken, then only miss.
+	deadline := time.Now().Add(5 * time.Second)
+	for start := time.Now(); time.Since(start) < 60*time.Millisecond; {
+		c.Get("hot")
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; ; i++ {
+		c.Get("miss" + strconv.Itoa(i))
+		select {
+		case alert := <-alerts:
+			// A sample may straddle the switch, so only require a 20% drop.
+			if prev, current := alert[0], alert[1]; prev == 0 || current >= prev*0.8 {
+				t.Fatalf("alert(%v, %v), want a drop of more than 20%%", prev, current)
+			}
+			m.Stop()
+			return
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no alert after the hit rate dropped to zero")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,89 @@
+package lrucache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMoveToFrontAndBack(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		c.Put(key, "v")
+	}
+
+	if !c.MoveToFront("a") {
+		t.Fatal("MoveToFront(a) = false")
+	}
+	if !c.MoveToBack("c") {
+		t.Fatal("MoveToBack(c) = false")
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"a", "d", "b", "c"}) {
+		t.Fatalf("keys = %v, want [a d b c]", got)
+	}
+	checkIntegrity(t, c)
+
+	c.MoveToBack("a")
+	c.MoveToFront("a")
+	c.MoveToBack("c")
+	checkIntegrity(t, c)
+
+	c.Put("e", "v")
+	if c.Has("c") {
+		t.Fatal("MoveToBack did not make c the next victim")
+	}
+	if c.MoveToFront("missing") || c.MoveToBack("missing") {
+		t.Fatal("moved a missing key")
+	}
+}
+
+func TestMoveSkipsDeadEntries(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(4, WithClock(func() time.Time { return now }))
+	c.PutWithTTL("expired", "v", time.Minute)
+	c.Put("deleted", "v")
+	c.Put("live", "v")
+	c.SoftDelete("deleted")
+	now = now.Add(time.Hour)
+
+	before := listKeys(c)
+	for _, key := range []string{"expired", "deleted"} {
+		if c.MoveToFront(key) || c.MoveToBack(key) {
+			t.Fatalf("moved dead entry %s", key)
+		}
+	}
+	if got := listKeys(c); !slices.Equal(got, before) {
+		t.Fatalf("keys = %v, want %v unchanged", got, before)
+	}
+}
+
+func TestMoveToBackMRU(t *testing.T) {
+	c, _ := NewMRUCache(3)
+	for _, key := range []string{"a", "b", "c"} {
+		c.Put(key, "v")
+	}
+
+	c.MoveToBack("a")
+	checkIntegrity(t, c)
+	c.Put("d", "v")
+	if c.Has("a") {
+		t.Fatal("MoveToBack did not make a the next victim of an MRU cache")
+	}
+	if !c.Has("b") || !c.Has("c") {
+		t.Fatalf("keys = %v, want b and c kept", listKeys(c))
+	}
+}
+
+func TestMoveWithSegments(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithSegments(0.2))
+	for i := range 10 {
+		c.Put(string(rune('a'+i)), "v")
+	}
+	for _, key := range []string{"a", "b", "c", "j", "a"} {
+		c.MoveToFront(key)
+		checkSegments(t, c)
+		c.MoveToBack(key)
+		checkSegments(t, c)
+		checkIntegrity(t, c)
+	}
+}
@@ -0,0 +1,81 @@
+package lrucache
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Key normalizers for use with WithKeyNormalizer.
+
+// LowerCaseKeys is a key normalizer that folds keys to lower case,
+// so "Product_1" and "product_1" refer to the same entry.
+func LowerCaseKeys(key string) string {
+	return strings.ToLower(key)
+}
+
+// TrimSpaceKeys is a key normalizer that drops leading and trailing white space,
+// so " product_1 " and "product_1" refer to the same entry.
+func TrimSpaceKeys(key string) string {
+	return strings.TrimSpace(key)
+}
+
+// ComposeNormalizers returns a key normalizer applying fns in order, e.g.
+// ComposeNormalizers(TrimSpaceKeys, LowerCaseKeys) to make " Product_1 " and
+// "product_1" share an entry.
+func ComposeNormalizers(fns ...func(string) string) func(string) string {
+	return func(key string) string {
+		for _, fn := range fns {
+			key = fn(key)
+		}
+		return key
+	}
+}
+
+// CanonicalURLKeys is a key normalizer for URL keys. It lower-cases the scheme
+// and host, drops default ports and the fragment, sorts the query parameters
+// and uses "/" for an empty path, so "HTTP://Example.com:80?b=2&a=1" and
+// "http://example.com/?a=1&b=2" share an entry. Query parameters are sorted
+// by name as written, without decoding them, so malformed pairs are kept and
+// repeated names keep their order. The path keeps its case;
+// compose with LowerCaseKeys if your paths are case-insensitive.
+// Keys that do not parse as absolute URLs are returned unchanged.
+func CanonicalURLKeys(key string) string {
+	u, err := url.Parse(strings.TrimSpace(key))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return key
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	}
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = canonicalQuery(u.RawQuery)
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// canonicalQuery sorts the pairs of a raw query by their raw name, keeping
+// pairs with the same name in order and dropping empty ones.
+func canonicalQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	pairs := strings.Split(raw, "&")
+	pairs = slices.DeleteFunc(pairs, func(pair string) bool { return pair == "" })
+	slices.SortStableFunc(pairs, func(a, b string) int {
+		nameA, _, _ := strings.Cut(a, "=")
+		nameB, _, _ := strings.Cut(b, "=")
+		return strings.Compare(nameA, nameB)
+	})
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,84 @@
+package lrucache
+
+import "testing"
+
+func TestLowerCaseKeysCollapse(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(4, WithKeyNormalizer(LowerCaseKeys))
+	c.Put("Product_1", "a")
+	c.Put("PRODUCT_1", "b")
+	c.Put("product_1", "c")
+
+	if got := c.Size(); got != 1 {
+		t.Fatalf("Size() = %d, want 1 entry for differently-cased keys", got)
+	}
+	for _, key := range []string{"product_1", "Product_1", "pRoDuCt_1"} {
+		if got, ok := c.Get(key); !ok || got != "c" {
+			t.Fatalf("Get(%q) = %q, %v, want \"c\", true", key, got, ok)
+		}
+	}
+	if !c.Delete("PRODUCT_1") || c.Has("product_1") {
+		t.Fatal("Delete with a differently-cased key did not remove the entry")
+	}
+}
+
+func TestComposeNormalizers(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(4, WithKeyNormalizer(ComposeNormalizers(TrimSpaceKeys, LowerCaseKeys)))
+	c.Put(" Product_1 ", "a")
+	c.Put("product_1", "b")
+	c.Put("\tPRODUCT_1\n", "c")
+
+	if got := c.Size(); got != 1 {
+		t.Fatalf("Size() = %d, want 1 entry for keys differing in case and space", got)
+	}
+	for _, key := range []string{"product_1", "  Product_1", "PRODUCT_1 "} {
+		if got, ok := c.Get(key); !ok || got != "c" {
+			t.Fatalf("Get(%q) = %q, %v, want \"c\", true", key, got, ok)
+		}
+	}
+	if !c.Has(" product_1") || !c.Delete("Product_1 ") || c.Has("product_1") {
+		t.Fatal("Has and Delete did not normalize their keys")
+	}
+
+	if got := TrimSpaceKeys(" a b "); got != "a b" {
+		t.Fatalf("TrimSpaceKeys = %q, want inner space kept", got)
+	}
+	if got := ComposeNormalizers()("Key"); got != "Key" {
+		t.Fatalf("ComposeNormalizers() = %q, want the key unchanged", got)
+	}
+	// Normalizers run in the order given
+	suffix := func(key string) string { return key + "!" }
+	if got := ComposeNormalizers(suffix, TrimSpaceKeys)(" k "); got != "k !" {
+		t.Fatalf("ComposeNormalizers(suffix, TrimSpaceKeys) = %q, want \"k !\"", got)
+	}
+}
+
+func TestCanonicalURLKeys(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"HTTP://Example.com:80?b=2&a=1", "http://example.com/?a=1&b=2"},
+		{"https://example.com:443/Path#frag", "https://example.com/Path"},
+		{"https://example.com:8443/", "https://example.com:8443/"},
+		{"http://[::1]:80/x", "http://[::1]/x"},
+		{"http://example.com/?b=%zz&a=1", "http://example.com/?a=1&b=%zz"},
+		{"http://example.com/?b=1;c=2&a=1", "http://example.com/?a=1&b=1;c=2"},
+		{"http://example.com/?a=2&b=1&a=1", "http://example.com/?a=2&a=1&b=1"},
+		{"http://example.com/?b=1&&a", "http://example.com/?a&b=1"},
+		{"not a url", "not a url"},
+		{"/relative?b=1&a=2", "/relative?b=1&a=2"},
+	} {
+		if got := CanonicalURLKeys(tc.in); got != tc.want {
+			t.Errorf("CanonicalURLKeys(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCanonicalURLKeysKeepMalformedPairsApart(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(4, WithKeyNormalizer(CanonicalURLKeys))
+	c.Put("http://example.com/?a=1&b=%zz", "one")
+	c.Put("http://example.com/?a=1&b=%yy", "two")
+	if c.Size() != 2 {
+		t.Fatalf("Size() = %d, want 2 entries for different malformed queries", c.Size())
+	}
+	if got, _ := c.Get("http://EXAMPLE.com/?b=%zz&a=1"); got != "one" {
+		t.Fatalf("Get = %q, want one", got)
+	}
+}
@@ -0,0 +1,99 @@
+package lrucache
+
+import "time"
+
+// Option configures an LRUCache created with NewLRUCacheWithOptions.
+type Option func(*LRUCache)
+
+// NewLRUCacheWithOptions creates a new LRUCache Instance with the specified capacity
+// and applies the given options in order.
+func NewLRUCacheWithOptions(capacity int, opts ...Option) (*LRUCache, error) {
+	cache, err := NewLRUCache(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(cache)
+	}
+	return cache, nil
+}
+
+// WithKeyNormalizer applies fn to every key passed to Put, Get, Delete and Has,
+// so keys that normalize to the same string share one entry.
+// Use LowerCaseKeys for case-insensitive keys.
+func WithKeyNormalizer(fn func(string) string) Option {
+	return func(c *LRUCache) {
+		c.normalize = fn
+	}
+}
+
+// WithClock replaces time.Now as the source of time for TTLs and ages.
+// Mostly useful to drive the cache from a fake clock in tests.
+func WithClock(now func() time.Time) Option {
+	return func(c *LRUCache) {
+		c.now = now
+	}
+}
+
+// WithMaxBytes bounds the total size of the stored keys, values and metadata in
+// bytes, on top of the entry capacity. Least recently used entries are evicted
+// until a new entry fits, and entries larger than the limit on their own are rejected.
+func WithMaxBytes(n int64) Option {
+	return func(c *LRUCache) {
+		c.maxBytes = n
+	}
+}
+
+// WithPromotionInterval only moves an entry to the head of the list on every
+// nth hit instead of on each one, sparing the list surgery for hot keys that
+// already sit near the head. Each entry counts its hits since it was last
+// moved; the nth one moves it and resets the count, earlier ones only refresh
+// its access time. Puts still move the entry immediately. Lookup results are
+// unchanged, only the eviction order becomes less precise. Values below 2
+// promote on every hit, the default.
+func WithPromotionInterval(n int) Option {
+	return func(c *LRUCache) {
+		c.promotion = n
+	}
+}
+
+// WithPromotionProbability only moves an entry to the head of the list on a
+// random fraction p of its hits, trading eviction accuracy for less list
+// surgery on very hot caches; the other hits only refresh its access time.
+// It combines with WithPromotionInterval, which counts the sampled hits.
+// Values outside (0, 1) promote on every hit, the default.
+func WithPromotionProbability(p float64) Option {
+	return func(c *LRUCache) {
+		if p > 0 && p < 1 {
+			c.promotionP = p
+		}
+	}
+}
+
+// SkipMoveToHead makes Get move an entry to the head with the given
+// probability, e.g. 0.1 for one hit in ten. It is WithPromotionProbability
+// under the name the option was first asked for.
+func SkipMoveToHead(probability float64) Option {
+	return WithPromotionProbability(probability)
+}
+
+// WithMaxKeyBytes rejects keys longer than n bytes, after normalization, so
+// clients cannot grow the cache with giant keys. PutE reports the rejection.
+func WithMaxKeyBytes(n int) Option {
+	return func(c *LRUCache) {
+		c.maxKeyBytes = n
+	}
+}
+
+// WithEvictionLookback makes eviction consider the last k entries of the list
+// and evict the one with the highest cost (key, value and metadata bytes)
+// instead of strictly the tail, so one huge entry goes before many small hot
+// ones. Each eviction stays O(k). The default of 1 is pure LRU; values below 1 are ignored.
+func WithEvictionLookback(k int) Option {
+	return func(c *LRUCache) {
+		if k >= 1 {
+			c.lookback = k
+		}
+	}
+}
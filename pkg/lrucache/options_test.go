@@ -0,0 +1,124 @@
+package lrucache
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOptionsTakeEffect(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var r recorder
+	loads := 0
+	c, err := NewLRUCacheWithOptions(10,
+		WithClock(func() time.Time { return now }),
+		WithMaxBytes(64),
+		WithOnEvict(r.onEvict),
+		WithKeyNormalizer(strings.ToLower),
+		WithLoader(func(key string) (string, error) {
+			loads++
+			return "loaded-" + key, nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// WithLoader: a miss is loaded once and then served from the cache.
+	for range 2 {
+		if value, err := c.GetOrLoad("x"); err != nil || value != "loaded-x" {
+			t.Fatalf("GetOrLoad = %q, %v, want loaded-x", value, err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("loader called %d times, want 1", loads)
+	}
+
+	// WithKeyNormalizer: keys differing in case share an entry.
+	c.Put("Key", "v")
+	if value, ok := c.Get("KEY"); !ok || value != "v" {
+		t.Fatalf("Get(KEY) = %q, %v, want v", value, ok)
+	}
+
+	// WithClock: entry ages come from the injected clock.
+	now = now.Add(time.Hour)
+	if removed := c.EvictOlderThan(time.Minute); removed != 2 {
+		t.Fatalf("EvictOlderThan removed %d entries, want 2", removed)
+	}
+
+	// WithMaxBytes: oversized entries are rejected, and the byte limit evicts.
+	if err := c.PutE("big", strings.Repeat("v", 100)); !errors.Is(err, ErrOversizedValue) {
+		t.Fatalf("oversized Put error = %v, want ErrOversizedValue", err)
+	}
+	c.Put("a", strings.Repeat("a", 40))
+	c.Put("b", strings.Repeat("b", 40))
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a survived a Put over the byte limit")
+	}
+
+	// WithOnEvict: every removal above was reported.
+	reasons := make(map[string]EvictionReason)
+	for _, e := range r.got() {
+		reasons[e.key] = e.reason
+	}
+	want := map[string]EvictionReason{"x": ReasonExpired, "key": ReasonExpired, "a": ReasonCapacity}
+	if len(reasons) != len(want) {
+		t.Fatalf("evictions = %v, want %v", reasons, want)
+	}
+	for key, reason := range want {
+		if reasons[key] != reason {
+			t.Fatalf("evictions = %v, want %v", reasons, want)
+		}
+	}
+}
+
+func TestOptionsApplyInOrder(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(2, WithKeyNormalizer(strings.ToLower), WithKeyNormalizer(nil))
+	c.Put("A", "1")
+	c.Put("a", "2")
+	if got := listKeys(c); !slices.Equal(got, []string{"a", "A"}) {
+		t.Fatalf("keys = %v, want the later option to win", got)
+	}
+}
+
+func TestLoaderPanicReleasesWaiters(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c, _ := NewLRUCacheWithOptions(10, WithLoader(func(key string) (string, error) {
+		close(started)
+		<-release
+		panic("boom")
+	}))
+
+	errs := make(chan error, 3)
+	go func() {
+		_, err := c.GetOrLoad("k")
+		errs <- err
+	}()
+	<-started
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetOrLoad("k")
+			errs <- err
+		}()
+	}
+	time.Sleep(10 * time.Millisecond) // let the waiters join the call
+	close(release)
+	wg.Wait()
+
+	for range 3 {
+		if err := <-errs; !errors.Is(err, ErrLoaderPanic) {
+			t.Fatalf("GetOrLoad error = %v, want ErrLoaderPanic", err)
+		}
+	}
+	if len(c.loads.calls) != 0 {
+		t.Fatalf("%d loader calls left in flight", len(c.loads.calls))
+	}
+}
@@ -0,0 +1,47 @@
+package lrucache
+
+// Pin exempts key from capacity eviction until it is unpinned or removed
+// explicitly, e.g. for configuration defaults that must stay cached. Evictions
+// pass over pinned entries and take the next candidate instead; when every
+// entry is pinned, Put and Resize leave the cache over capacity. Pinned
+// entries still expire, and are still subject to Delete and Clear.
+// Returns false if the key is absent.
+func (c *LRUCache) Pin(key string) bool {
+	return c.setPinned(key, true)
+}
+
+// Unpin makes a pinned key evictable again.
+// Returns false if the key is absent.
+func (c *LRUCache) Unpin(key string) bool {
+	return c.setPinned(key, false)
+}
+
+func (c *LRUCache) setPinned(key string, pinned bool) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || !c.live(node) {
+		return false
+	}
+	node.pinned = pinned
+	if !pinned {
+		// The cache may have grown past its capacity while the key was pinned
+		c.evictOverflow(0, 0)
+	}
+	return true
+}
+
+// unpinnedFrom returns the first unpinned node from node towards the head,
+// or towards the tail if forward is set, nil if there is none.
+func unpinnedFrom(node *Node, forward bool) *Node {
+	for node != nil && node.pinned {
+		if forward {
+			node = node.Next
+		} else {
+			node = node.Prev
+		}
+	}
+	return node
+}
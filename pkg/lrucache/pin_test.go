@@ -0,0 +1,61 @@
+package lrucache
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPinnedTailSurvives(t *testing.T) {
+	var r recorder
+	c, _ := NewLRUCacheWithOptions(3, WithOnEvict(r.onEvict))
+	c.Put("config", "default")
+	c.Put("a", "1")
+	c.Put("b", "2")
+	if !c.Pin("config") {
+		t.Fatal("Pin(config) = false")
+	}
+
+	for _, key := range []string{"c", "d", "e"} {
+		c.Put(key, "v")
+	}
+	if !c.Has("config") {
+		t.Fatal("the pinned tail entry was evicted")
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"e", "d", "config"}) {
+		t.Fatalf("keys = %v, want [e d config]", got)
+	}
+	for _, e := range r.got() {
+		if e.key == "config" {
+			t.Fatal("the pinned entry was reported evicted")
+		}
+	}
+	checkIntegrity(t, c)
+
+	if c.Pin("missing") || c.Unpin("missing") {
+		t.Fatal("pinned a missing key")
+	}
+}
+
+func TestAllPinnedGoesOverCapacity(t *testing.T) {
+	c, _ := NewLRUCache(2)
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Pin("a")
+	c.Pin("b")
+
+	c.Put("c", "3")
+	if c.Size() != 3 || !c.Has("a") || !c.Has("b") || !c.Has("c") {
+		t.Fatalf("keys = %v, want the Put to go over capacity", listKeys(c))
+	}
+
+	// Unpinning brings the cache back within capacity.
+	c.Unpin("a")
+	if c.Size() != 2 || c.Has("a") {
+		t.Fatalf("keys = %v after Unpin(a), want a evicted", listKeys(c))
+	}
+
+	// Pinned entries still go on Delete.
+	if !c.Delete("b") || c.Has("b") {
+		t.Fatal("Delete did not remove a pinned entry")
+	}
+}
@@ -0,0 +1,203 @@
+package lrucache
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// PriorityCache evicts the entry with the lowest priority when full, the
+// least recently used one among ties. Reads refresh recency but never change
+// an entry's priority. Useful for weighted caching, e.g. keeping expensive
+// origin responses over cheap ones.
+type PriorityCache struct {
+	capacity int
+	entries  map[string]*priorityEntry
+	heap     priorityHeap
+	mutex    sync.Mutex
+	seq      uint64
+}
+
+type priorityEntry struct {
+	key      string
+	value    string
+	priority int
+	seq      uint64 // last access, breaks ties
+	index    int    // position in the heap
+}
+
+var _ Cache = (*PriorityCache)(nil)
+
+// NewPriorityCache creates a new PriorityCache Instance with the specified capacity.
+func NewPriorityCache(capacity int) (*PriorityCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &PriorityCache{
+		capacity: capacity,
+		entries:  make(map[string]*priorityEntry),
+	}, nil
+}
+
+// Get retrieves the value for a given key and marks it as recently used.
+func (c *PriorityCache) Get(key string) (string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.touch(entry)
+	return entry.value, true
+}
+
+// Put adds a key-value pair to the cache. New keys get priority 0 and
+// existing keys keep theirs.
+func (c *PriorityCache) Put(key string, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		c.touch(entry)
+		return
+	}
+	c.insert(key, value, 0)
+}
+
+// PutWithPriority adds or updates a key-value pair with the given priority,
+// evicting the lowest-priority entry when full.
+func (c *PriorityCache) PutWithPriority(key string, value string, priority int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		entry.priority = priority
+		c.touch(entry)
+		return
+	}
+	c.insert(key, value, priority)
+}
+
+// UpdatePriority changes the priority of key without touching its recency.
+// Returns false if the key is absent.
+func (c *PriorityCache) UpdatePriority(key string, priority int) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	entry.priority = priority
+	heap.Fix(&c.heap, entry.index)
+	return true
+}
+
+// Priority returns the priority of key.
+func (c *PriorityCache) Priority(key string) (int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	return entry.priority, true
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *PriorityCache) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	heap.Remove(&c.heap, entry.index)
+	delete(c.entries, key)
+	return true
+}
+
+// Has checks if the cache contains a specific key without marking it as used.
+func (c *PriorityCache) Has(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Clear removes all items from the cache.
+func (c *PriorityCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*priorityEntry)
+	c.heap = nil
+}
+
+// Size returns the current number of items in the cache.
+func (c *PriorityCache) Size() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// insert adds a new entry, evicting the lowest-priority one when full.
+// The caller must hold the mutex.
+func (c *PriorityCache) insert(key string, value string, priority int) {
+	if len(c.entries) >= c.capacity {
+		victim := heap.Pop(&c.heap).(*priorityEntry)
+		delete(c.entries, victim.key)
+	}
+
+	c.seq++
+	entry := &priorityEntry{key: key, value: value, priority: priority, seq: c.seq}
+	c.entries[key] = entry
+	heap.Push(&c.heap, entry)
+}
+
+// touch marks an entry as the most recently used. The caller must hold the mutex.
+func (c *PriorityCache) touch(entry *priorityEntry) {
+	c.seq++
+	entry.seq = c.seq
+	heap.Fix(&c.heap, entry.index)
+}
+
+// priorityHeap is a min-heap of entries by priority, then by last access.
+type priorityHeap []*priorityEntry
+
+func (h priorityHeap) Len() int { return len(h) }
+
+func (h priorityHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityHeap) Push(x any) {
+	entry := x.(*priorityEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *priorityHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,98 @@
+package lrucache
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxProfileEvents bounds the ring buffer of a Profiler.
+const maxProfileEvents = 1_000_000
+
+// ProfileEvent is a single recorded cache access.
+type ProfileEvent struct {
+	Op      string    `json:"op"` // "get" or "put"
+	Key     string    `json:"key"`
+	Hit     bool      `json:"hit,omitempty"`
+	Evicted bool      `json:"evicted,omitempty"` // the put evicted another entry
+	Time    time.Time `json:"ts"`
+}
+
+// Profiler records the access pattern of a cache for offline analysis,
+// keeping the most recent events in a ring buffer.
+type Profiler struct {
+	cache  *LRUCache
+	mutex  sync.Mutex
+	events []ProfileEvent
+	next   int // ring position once the buffer is full
+}
+
+// StartProfiling starts recording every Get and Put on cache.
+// It replaces any profiler already attached to the cache.
+func StartProfiling(cache *LRUCache) *Profiler {
+	p := &Profiler{cache: cache}
+	cache.profiler.Store(p)
+	return p
+}
+
+// Stop detaches the profiler from the cache. Events recorded so far stay available.
+func (p *Profiler) Stop() {
+	p.cache.profiler.CompareAndSwap(p, nil)
+}
+
+// record appends an event, overwriting the oldest one once the buffer is full.
+func (p *Profiler) record(event ProfileEvent) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.events) < maxProfileEvents {
+		p.events = append(p.events, event)
+		return
+	}
+	p.events[p.next] = event
+	p.next = (p.next + 1) % maxProfileEvents
+}
+
+// Events returns the recorded events, oldest first.
+func (p *Profiler) Events() []ProfileEvent {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	events := make([]ProfileEvent, 0, len(p.events))
+	events = append(events, p.events[p.next:]...)
+	return append(events, p.events[:p.next]...)
+}
+
+// Dump writes the recorded events as JSON Lines, oldest first.
+func (p *Profiler) Dump(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, event := range p.Events() {
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AnalyzeHitRate returns the hit rate, in percent, of the Gets recorded
+// within the last window.
+func (p *Profiler) AnalyzeHitRate(window time.Duration) float64 {
+	cutoff := p.cache.now().Add(-window)
+
+	var hits, total int
+	for _, event := range p.Events() {
+		if event.Op != "get" || event.Time.Before(cutoff) {
+			continue
+		}
+		total++
+		if event.Hit {
+			hits++
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return float64(hits) / float64(total) * 100
+}
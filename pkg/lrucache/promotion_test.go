@@ -0,0 +1,148 @@
+package lrucache
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// timingLocker adds up how long the write lock of the wrapped mutex is held.
+type timingLocker struct {
+	sync.RWMutex
+	since time.Time
+	held  atomic.Int64 // nanoseconds
+}
+
+func (l *timingLocker) Lock() {
+	l.RWMutex.Lock()
+	l.since = time.Now()
+}
+
+func (l *timingLocker) Unlock() {
+	l.held.Add(int64(time.Since(l.since)))
+	l.RWMutex.Unlock()
+}
+
+// zipfTrace returns n lookups over keys with a Zipf distribution, so a few
+// keys are very hot, with a fixed seed so runs are comparable.
+func zipfTrace(n int, keys []string) []string {
+	zipf := rand.NewZipf(rand.New(rand.NewPCG(1, 2)), 1.1, 1, uint64(len(keys)-1))
+	trace := make([]string, n)
+	for i := range trace {
+		trace[i] = keys[zipf.Uint64()]
+	}
+	return trace
+}
+
+// traceHitRate replays trace read-through against c and returns the hit rate.
+func traceHitRate(c *LRUCache, trace []string) float64 {
+	hits := 0
+	for _, key := range trace {
+		if _, ok := c.Get(key); ok {
+			hits++
+		} else {
+			c.Put(key, key)
+		}
+	}
+	return float64(hits) / float64(len(trace))
+}
+
+func TestPromotionIntervalHitRate(t *testing.T) {
+	trace := zipfTrace(200_000, benchKeys(10_000))
+	exact, _ := NewLRUCache(1000)
+	baseline := traceHitRate(exact, trace)
+
+	for _, n := range []int{2, 4, 8} {
+		c, _ := NewLRUCacheWithOptions(1000, WithPromotionInterval(n))
+		rate := traceHitRate(c, trace)
+		t.Logf("interval %d: hit rate %.4f, exact LRU %.4f", n, rate, baseline)
+		if baseline-rate > 0.03 {
+			t.Fatalf("interval %d: hit rate %.4f, more than 3 points below exact LRU at %.4f", n, rate, baseline)
+		}
+	}
+}
+
+func TestPromotionIntervalMovesEveryNthHit(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(3, WithPromotionInterval(3))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	for hit := 1; hit <= 3; hit++ {
+		c.Get("a")
+		if moved := c.Head.Key == "a"; moved != (hit == 3) {
+			t.Fatalf("after hit %d a is at the head: %v", hit, moved)
+		}
+	}
+}
+
+func TestSkipMoveToHead(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(3, SkipMoveToHead(0.1))
+	if c.promotionP != 0.1 {
+		t.Fatalf("promotionP = %v, want 0.1", c.promotionP)
+	}
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	moves := 0
+	for range 1000 {
+		c.Get("a")
+		if c.Head.Key == "a" {
+			moves++
+			c.MoveToBack("a")
+		}
+	}
+	if moves < 50 || moves > 150 {
+		t.Fatalf("a moved to the head on %d of 1000 hits, want about 100", moves)
+	}
+}
+
+// BenchmarkPromotionInterval replays a skewed read-through workload at
+// several promotion intervals and reports the time spent holding the write
+// lock per lookup next to the hit rate.
+func BenchmarkPromotionInterval(b *testing.B) {
+	trace := zipfTrace(1<<16, benchKeys(10_000))
+	for _, n := range []int{1, 2, 4, 8, 16} {
+		b.Run("interval="+strconv.Itoa(n), func(b *testing.B) {
+			benchPromotion(b, trace, WithPromotionInterval(n))
+		})
+	}
+}
+
+// BenchmarkPromotionProbability is BenchmarkPromotionInterval for
+// WithPromotionProbability, where p=1 is exact LRU.
+func BenchmarkPromotionProbability(b *testing.B) {
+	trace := zipfTrace(1<<16, benchKeys(10_000))
+	for _, p := range []float64{1, 0.5, 0.25, 0.1, 0.01} {
+		b.Run("p="+strconv.FormatFloat(p, 'g', -1, 64), func(b *testing.B) {
+			benchPromotion(b, trace, WithPromotionProbability(p))
+		})
+	}
+}
+
+// benchPromotion replays trace read-through against a cache built with opt,
+// reporting the write lock hold time per lookup and the hit rate.
+func benchPromotion(b *testing.B, trace []string, opt Option) {
+	c, _ := NewLRUCacheWithOptions(1000, opt)
+	traceHitRate(c, trace) // warm up
+	lock := &timingLocker{}
+	c.mutex = lock
+	stats := c.Stats()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		key := trace[i%len(trace)]
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, key)
+		}
+	}
+	b.StopTimer()
+
+	after := c.Stats()
+	hits, misses := after.Hits-stats.Hits, after.Misses-stats.Misses
+	b.ReportMetric(float64(lock.held.Load())/float64(b.N), "lock-ns/op")
+	b.ReportMetric(float64(hits)/float64(hits+misses), "hits/op")
+}
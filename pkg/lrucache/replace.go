@@ -0,0 +1,236 @@
+package lrucache
+
+import (
+	"iter"
+	"sort"
+)
+
+// Entry is a single key-value pair stored in the cache.
+type Entry struct {
+	Key   string            `json:"key"`
+	Value string            `json:"value"`
+	Meta  map[string]string `json:"meta,omitempty"`
+	Cost  int64             `json:"cost,omitempty"` // bytes charged against the byte limit, ignored on input
+}
+
+// entry copies a node into an Entry.
+func (n *Node) entry() Entry {
+	return Entry{Key: n.Key, Value: n.Value, Meta: copyMeta(n.meta), Cost: n.cost}
+}
+
+// ReplaceAll atomically swaps the entire contents of the cache.
+// Under a single write lock it clears the current entries and inserts the new
+// ones in order, so later entries end up more recent and the earliest ones are
+// trimmed first if the slice exceeds the capacity. Entries rejected by the byte
+// limit are skipped.
+// Concurrent readers observe either the old or the new set, never a mix.
+func (c *LRUCache) ReplaceAll(entries []Entry) {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	c.reset()
+	for _, entry := range entries {
+		_ = c.put(c.normalizeKey(entry.Key), entry.Value, entry.Meta, c.ttl)
+	}
+}
+
+// Drain atomically empties the cache and returns its entries in LRU order,
+// least recently used first. The eviction callbacks fire for every drained entry
+// once the lock is released. Useful to flush the cache to disk or a queue on shutdown.
+func (c *LRUCache) Drain() []Entry {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	entries := make([]Entry, 0, len(c.Cache))
+	for node := c.Tail; node != nil; node = node.Prev {
+		if node.deleted {
+			continue
+		}
+		entries = append(entries, node.entry())
+		c.notify(node, ReasonDrained)
+	}
+
+	c.reset()
+	return entries
+}
+
+// ResizeDrainAndReload changes the capacity like Resize, but chooses what
+// survives by priority instead of recency: the live entries are ranked by
+// priority, highest first, and the top capacity of them are kept while the
+// rest are evicted with ReasonCapacity and returned, highest priority first.
+// Entries of equal priority are ranked by recency. Like Resize, it never
+// evicts pinned entries, which may leave the cache over capacity, and keys
+// within a ReserveSlots reservation only compete with keys of the same
+// prefix for its slots; the other entries share what remains. Kept entries
+// retain their position in the recency order, TTL and metadata. Expired and
+// soft-deleted entries are removed along the way. The whole operation runs
+// under the write lock, so priority must not call back into the cache.
+func (c *LRUCache) ResizeDrainAndReload(capacity int, priority func(key, value string) int) ([]Entry, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	c.mutex.Lock()
+	defer c.unlock()
+
+	type ranked struct {
+		node     *Node
+		priority int
+	}
+	nodes := make([]ranked, 0, len(c.Cache))
+	for node := c.Head; node != nil; {
+		next := node.Next
+		switch {
+		case node.deleted:
+			c.removeEntry(node, ReasonDeleted)
+		case c.expired(node):
+			c.removeEntry(node, ReasonExpired)
+		default:
+			nodes = append(nodes, ranked{node: node, priority: priority(node.Key, node.Value)})
+		}
+		node = next
+	}
+	// Pinned entries claim their slots first, whatever their priority
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].node.pinned != nodes[j].node.pinned {
+			return nodes[i].node.pinned
+		}
+		return nodes[i].priority > nodes[j].priority
+	})
+
+	// Reserved slots are set aside, as in evictUnreserved
+	unreserved, inGroup := capacity, map[string]int(nil)
+	if c.reserve != nil {
+		unreserved -= c.reserve.total
+		inGroup = make(map[string]int, len(c.reserve.slots))
+	}
+	var dropped []Entry
+	for _, r := range nodes {
+		if c.reserve != nil {
+			if group, ok := c.reserve.group(r.node.Key); ok && inGroup[group] < c.reserve.slots[group] {
+				inGroup[group]++
+				continue
+			}
+		}
+		if r.node.pinned || unreserved > 0 {
+			unreserved--
+			continue
+		}
+		dropped = append(dropped, r.node.entry())
+		c.removeEntry(r.node, ReasonCapacity)
+		c.recordEviction()
+	}
+	c.resize(capacity)
+	return dropped, nil
+}
+
+// entries copies the live entries in list order, most recently used first.
+// The caller must hold at least the read lock.
+func (c *LRUCache) entries() []Entry {
+	entries := make([]Entry, 0, len(c.Cache))
+	for node := c.Head; node != nil; node = node.Next {
+		if !c.live(node) {
+			continue
+		}
+		entries = append(entries, node.entry())
+	}
+	return entries
+}
+
+// All returns an iterator over the key-value pairs, most recently used first:
+//
+//	for key, value := range cache.All() { ... }
+//
+// The entries are copied under the read lock when iteration starts, and the
+// lock is released before the loop body runs, so it may freely call back into
+// the cache. The snapshot may therefore be stale by the time iteration
+// finishes. The copy costs one Entry per cached item; the keys and values
+// are not duplicated.
+func (c *LRUCache) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		c.mutex.RLock()
+		entries := c.entries()
+		c.mutex.RUnlock()
+
+		for _, entry := range entries {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// KeysIter returns an iterator over the keys, most recently used first,
+// with the same snapshot semantics as All.
+func (c *LRUCache) KeysIter() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for key := range c.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// SnapshotRange calls fn for each entry, most recently used first, until fn
+// returns false. It is the callback form of All, with the same snapshot semantics.
+func (c *LRUCache) SnapshotRange(fn func(key, value string) bool) {
+	for key, value := range c.All() {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// ForEach calls fn for each entry, most recently used first, until fn returns
+// false. Unlike All it copies nothing: fn runs under the read lock, so it must
+// not call back into the cache, and writers wait until the walk ends.
+func (c *LRUCache) ForEach(fn func(key, value string) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for node := c.Head; node != nil; node = node.Next {
+		if c.live(node) && !fn(node.Key, node.Value) {
+			return
+		}
+	}
+}
+
+// ForEachOldest is like ForEach but walks from the least recently used entry
+// to the most recent, e.g. to write entries to disk in eviction order.
+func (c *LRUCache) ForEachOldest(fn func(key, value string) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for node := c.Tail; node != nil; node = node.Prev {
+		if c.live(node) && !fn(node.Key, node.Value) {
+			return
+		}
+	}
+}
+
+// Keys returns the live keys in list order, most recently used first.
+// The order never depends on map iteration.
+func (c *LRUCache) Keys() []string {
+	keys := []string{}
+	for key := range c.KeysIter() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SortedKeys returns the live keys in lexical order, which unlike Keys does
+// not depend on the access pattern. Useful for golden-file tests.
+func (c *LRUCache) SortedKeys() []string {
+	keys := c.Keys()
+	sort.Strings(keys)
+	return keys
+}
+
+// Entries returns a copy of the live entries in list order, most recently used first.
+func (c *LRUCache) Entries() []Entry {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.entries()
+}
@@ -0,0 +1,214 @@
+package lrucache
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReplaceAll(t *testing.T) {
+	c, _ := NewLRUCache(3)
+	c.Put("old", "x")
+
+	c.ReplaceAll([]Entry{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}, {Key: "c", Value: "3"}, {Key: "d", Value: "4"}})
+	if got, want := c.Keys(), []string{"d", "c", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+	checkIntegrity(t, c)
+}
+
+// TestReplaceAllIsAtomic swaps between two generations of entries while
+// readers take snapshots, which must always hold exactly one generation.
+func TestReplaceAllIsAtomic(t *testing.T) {
+	const size = 100
+	generation := func(gen int) []Entry {
+		entries := make([]Entry, size)
+		for i := range entries {
+			entries[i] = Entry{Key: "k" + strconv.Itoa(i+gen*size), Value: strconv.Itoa(gen)}
+		}
+		return entries
+	}
+	gens := [][]Entry{generation(0), generation(1)}
+
+	c, _ := NewLRUCache(size)
+	c.ReplaceAll(gens[0])
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				entries := c.Entries()
+				if len(entries) != size {
+					t.Errorf("snapshot has %d entries, want %d", len(entries), size)
+					return
+				}
+				for _, entry := range entries {
+					if entry.Value != entries[0].Value {
+						t.Errorf("snapshot mixes generations %s and %s", entries[0].Value, entry.Value)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		c.ReplaceAll(gens[i%2])
+	}
+	close(stop)
+	wg.Wait()
+	checkIntegrity(t, c)
+}
+
+func TestSnapshotRangeAllowsWrites(t *testing.T) {
+	c, _ := NewLRUCache(100)
+	for i := range 5 {
+		c.Put(strconv.Itoa(i), "v")
+	}
+
+	done := make(chan []string)
+	go func() {
+		var seen []string
+		c.SnapshotRange(func(key, value string) bool {
+			seen = append(seen, key)
+			c.Put("new-"+key, value)
+			c.Delete("0")
+			return true
+		})
+		done <- seen
+	}()
+
+	select {
+	case seen := <-done:
+		// The snapshot is fixed when iteration starts: writes made by the
+		// callback are not visited.
+		if want := []string{"4", "3", "2", "1", "0"}; !slices.Equal(seen, want) {
+			t.Fatalf("visited %v, want %v", seen, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SnapshotRange deadlocked when the callback wrote to the cache")
+	}
+	if c.Size() != 9 || c.Has("0") || !c.Has("new-0") {
+		t.Fatalf("keys after SnapshotRange = %v", c.Keys())
+	}
+
+	n := 0
+	c.SnapshotRange(func(string, string) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Fatalf("SnapshotRange called fn %d times after it returned false, want 3", n)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	keys := []string{"delta", "alpha", "charlie", "bravo", "echo"}
+	a, _ := NewLRUCache(10)
+	b, _ := NewLRUCache(10)
+	for i := range keys {
+		a.Put(keys[i], "v")
+		b.Put(keys[len(keys)-1-i], "v")
+	}
+	b.Get("alpha")
+	b.MoveToBack("echo")
+
+	want := []string{"alpha", "bravo", "charlie", "delta", "echo"}
+	for _, c := range []*LRUCache{a, b} {
+		if got := c.SortedKeys(); !slices.Equal(got, want) {
+			t.Fatalf("SortedKeys() = %v, want %v", got, want)
+		}
+	}
+	if slices.Equal(a.Keys(), b.Keys()) {
+		t.Fatal("Keys() ignored the access pattern")
+	}
+
+	c, _ := NewLRUCache(10)
+	if got := c.SortedKeys(); got == nil || len(got) != 0 {
+		t.Fatalf("SortedKeys() on an empty cache = %#v, want an empty slice", got)
+	}
+}
+
+// byValue ranks entries by their numeric value.
+func byValue(key, value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+func TestResizeDrainAndReload(t *testing.T) {
+	c, _ := NewLRUCache(5)
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		c.Put(key, strconv.Itoa(i))
+	}
+
+	dropped, err := c.ResizeDrainAndReload(3, byValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"e", "d", "c"}) {
+		t.Fatalf("keys = %v, want [e d c]", got)
+	}
+	if len(dropped) != 2 || dropped[0].Key != "b" || dropped[1].Key != "a" {
+		t.Fatalf("dropped = %v, want b then a", dropped)
+	}
+	checkIntegrity(t, c)
+}
+
+func TestResizeDrainAndReloadKeepsPinned(t *testing.T) {
+	c, _ := NewLRUCache(5)
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		c.Put(key, strconv.Itoa(i))
+	}
+	c.Pin("a")
+	c.Pin("b")
+
+	if _, err := c.ResizeDrainAndReload(3, byValue); err != nil {
+		t.Fatal(err)
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"e", "b", "a"}) {
+		t.Fatalf("keys = %v, want the pinned a and b plus the top-priority e", got)
+	}
+
+	// With every slot pinned, the cache stays over capacity like Resize
+	if _, err := c.ResizeDrainAndReload(1, byValue); err != nil {
+		t.Fatal(err)
+	}
+	if got := listKeys(c); !slices.Equal(got, []string{"b", "a"}) {
+		t.Fatalf("keys = %v, want only the pinned a and b", got)
+	}
+	checkIntegrity(t, c)
+}
+
+func TestResizeDrainAndReloadHonorsReservations(t *testing.T) {
+	c, _ := NewLRUCache(6)
+	for i, key := range []string{"vip_a", "vip_b", "c", "d", "e", "f"} {
+		c.Put(key, strconv.Itoa(i))
+	}
+	if err := c.ReserveSlots("vip_", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ResizeDrainAndReload(3, byValue); err != nil {
+		t.Fatal(err)
+	}
+	// vip_b takes the reserved slot; the other two slots go to the highest
+	// priorities among the rest, which vip_a cannot outrank.
+	if got := listKeys(c); !slices.Equal(got, []string{"f", "e", "vip_b"}) {
+		t.Fatalf("keys = %v, want [f e vip_b]", got)
+	}
+	c.Put("g", "9")
+	if !c.Has("vip_b") {
+		t.Fatal("a Put evicted the key in the reserved slot")
+	}
+	checkIntegrity(t, c)
+}
@@ -0,0 +1,142 @@
+package lrucache
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// replicaQueueSize is how many operations the replica may lag behind.
+const replicaQueueSize = 1024
+
+// replicaOp is one Put or Delete to apply to the replica.
+type replicaOp struct {
+	delete bool
+	key    string
+	value  string
+	meta   map[string]string
+	ttl    time.Duration
+
+	drained chan error // set on drain markers, which are not applied
+}
+
+// replication applies operations to a replica from a background goroutine.
+type replication struct {
+	ops  chan replicaOp
+	done chan struct{}
+
+	mu  sync.Mutex
+	err error // first rejected operation since the last drain
+}
+
+// WithReplicaTo mirrors every Put and Delete on the cache to replica, e.g. a
+// secondary serving reads. Operations are queued to a buffered channel and
+// applied in order by a background goroutine, so the replica lags behind by at
+// most replicaQueueSize operations; writers block while the queue is full.
+// Entries keep their metadata and remaining TTL. Evictions and Clear are not
+// replicated: the replica evicts by its own capacity. Call DrainReplica to
+// wait for the pending operations and Close to stop the goroutine. replica
+// must not replicate back to the cache.
+func WithReplicaTo(replica *LRUCache) Option {
+	return func(c *LRUCache) {
+		if replica == nil || replica == c {
+			return
+		}
+		r := &replication{
+			ops:  make(chan replicaOp, replicaQueueSize),
+			done: make(chan struct{}),
+		}
+		go r.run(replica)
+		c.replica = r
+	}
+}
+
+// run applies queued operations until the channel is closed.
+func (r *replication) run(replica *LRUCache) {
+	defer close(r.done)
+
+	for op := range r.ops {
+		if op.drained != nil {
+			op.drained <- r.drain()
+			continue
+		}
+		key := replica.normalizeKey(op.key)
+		if op.delete {
+			replica.Delete(key)
+			continue
+		}
+		if err := replica.set(key, op.value, op.meta, op.ttl); err != nil {
+			r.mu.Lock()
+			if r.err == nil {
+				r.err = err
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// drain returns and resets the first rejected operation.
+func (r *replication) drain() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.err
+	r.err = nil
+	return err
+}
+
+// DrainReplica blocks until every operation queued for the WithReplicaTo
+// replica so far has been applied, and returns the first error the replica
+// rejected a Put with since the previous drain, if any.
+func (c *LRUCache) DrainReplica() error {
+	c.mutex.RLock()
+	r := c.replica
+	if r == nil {
+		c.mutex.RUnlock()
+		return nil
+	}
+	drained := make(chan error, 1)
+	r.ops <- replicaOp{drained: drained}
+	c.mutex.RUnlock()
+
+	return <-drained
+}
+
+// stopReplica applies the pending operations and stops the replication
+// goroutine, if any.
+func (c *LRUCache) stopReplica() error {
+	c.mutex.Lock()
+	r := c.replica
+	c.replica = nil
+	c.mutex.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	close(r.ops)
+	<-r.done
+	return r.drain()
+}
+
+// replicatePut queues node as stored for the replica.
+// The caller must hold the write lock.
+func (c *LRUCache) replicatePut(node *Node) {
+	if c.replica == nil {
+		return
+	}
+	var ttl time.Duration
+	if !node.expiresAt.IsZero() {
+		if ttl = node.expiresAt.Sub(c.now()); ttl <= 0 {
+			return
+		}
+	}
+	c.replica.ops <- replicaOp{key: node.Key, value: node.Value, meta: maps.Clone(node.meta), ttl: ttl}
+}
+
+// replicateDelete queues the deletion of key for the replica.
+// The caller must hold the write lock.
+func (c *LRUCache) replicateDelete(key string) {
+	if c.replica != nil {
+		c.replica.ops <- replicaOp{delete: true, key: key}
+	}
+}
@@ -0,0 +1,147 @@
+package lrucache
+
+import (
+	"errors"
+	"maps"
+	"strings"
+)
+
+// reservations tracks the slots set aside with ReserveSlots.
+type reservations struct {
+	slots map[string]int // reserved slots per prefix
+	used  map[string]int // entries per prefix, each key counted under its longest reserved prefix
+	total int            // sum of slots
+}
+
+// ReserveSlots sets aside count slots of the capacity for keys starting with
+// prefix, e.g. "vip_". Up to count such keys are never evicted to make room;
+// the other entries, including prefixed keys beyond count, compete for the
+// remaining slots, and eviction skips reserved keys to take the next
+// evictable entry from the tail. A key matching several reserved prefixes
+// belongs to the longest one. Calling it again for a prefix replaces its
+// reservation, and a count of 0 removes it. Reservations must leave at least
+// one slot unreserved; entries beyond the new unreserved space are evicted
+// right away. Unbounded caches cannot reserve slots.
+func (c *LRUCache) ReserveSlots(prefix string, count int) error {
+	if prefix == "" {
+		return errors.New("invalid prefix: must not be empty")
+	}
+	if count < 0 {
+		return errors.New("invalid count: must not be negative")
+	}
+
+	c.mutex.Lock()
+	defer c.unlock()
+
+	if c.Capacity == NoLimit {
+		return errors.New("invalid reservation: the cache is unbounded")
+	}
+	if c.reserve == nil {
+		c.reserve = &reservations{slots: make(map[string]int)}
+	}
+	r := c.reserve
+	if total := r.total - r.slots[prefix] + count; total >= c.Capacity {
+		return errors.New("invalid reservation: must leave at least one unreserved slot")
+	}
+
+	r.total += count - r.slots[prefix]
+	if count == 0 {
+		delete(r.slots, prefix)
+	} else {
+		r.slots[prefix] = count
+	}
+	if len(r.slots) == 0 {
+		c.reserve = nil
+		return nil
+	}
+
+	r.used = make(map[string]int, len(r.slots))
+	for key := range c.Cache {
+		if group, ok := r.group(key); ok {
+			r.used[group]++
+		}
+	}
+	c.evictUnreserved(0)
+	return nil
+}
+
+// ListReservations returns the reserved slots per prefix.
+func (c *LRUCache) ListReservations() map[string]int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.reserve == nil {
+		return map[string]int{}
+	}
+	return maps.Clone(c.reserve.slots)
+}
+
+// group returns the longest reserved prefix of key.
+func (r *reservations) group(key string) (string, bool) {
+	best, found := "", false
+	for prefix := range r.slots {
+		if len(prefix) > len(best) && strings.HasPrefix(key, prefix) {
+			best, found = prefix, true
+		}
+	}
+	return best, found
+}
+
+// add counts key as stored, remove as gone.
+func (r *reservations) add(key string, delta int) {
+	if group, ok := r.group(key); ok {
+		r.used[group] += delta
+	}
+}
+
+// protects reports whether a key stored under group is within its reservation.
+func (r *reservations) protects(key string) bool {
+	group, ok := r.group(key)
+	return ok && r.used[group] <= r.slots[group]
+}
+
+// evictable returns the number of entries outside their reservation.
+func (r *reservations) evictable(size int) int {
+	for group, slots := range r.slots {
+		size -= min(r.used[group], slots)
+	}
+	return size
+}
+
+// unreservedVictim returns the least recently used unpinned entry outside its
+// reservation, nil if there is none. The caller must hold the write lock.
+func (c *LRUCache) unreservedVictim() *Node {
+	for node := c.Tail; node != nil; node = node.Prev {
+		if !node.pinned && !c.reserve.protects(node.Key) {
+			return node
+		}
+	}
+	return nil
+}
+
+// evictUnreserved evicts until entries more unreserved entries fit in the
+// slots left unreserved. The caller must hold the write lock.
+func (c *LRUCache) evictUnreserved(entries int) {
+	if c.reserve == nil {
+		return
+	}
+	for c.reserve.evictable(len(c.Cache))+entries > c.Capacity-c.reserve.total {
+		victim := c.unreservedVictim()
+		if victim == nil {
+			return
+		}
+		key := victim.Key
+		c.removeEntry(victim, ReasonCapacity)
+		c.recordEviction()
+		c.rememberGhost(key)
+	}
+}
+
+// reservedInsert reports how many unreserved slots a new entry under key takes.
+// The caller must hold the write lock.
+func (c *LRUCache) reservedInsert(key string) int {
+	if group, ok := c.reserve.group(key); ok && c.reserve.used[group] < c.reserve.slots[group] {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,110 @@
+package lrucache
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Scope is a request-scoped view of a cache. Its Get memoizes what the
+// parent returned, so repeated reads of a key within one request take the
+// parent's lock and promote the entry only once. A Scope is not safe for
+// concurrent use and must not outlive the request.
+type Scope struct {
+	parent *LRUCache
+	local  map[string]scopeEntry
+}
+
+type scopeEntry struct {
+	value string
+	ok    bool
+}
+
+var scopePool = sync.Pool{
+	New: func() any { return &Scope{local: make(map[string]scopeEntry)} },
+}
+
+// RequestScope returns an empty Scope over the cache.
+// Call Release when the request is done.
+func (c *LRUCache) RequestScope() *Scope {
+	s := scopePool.Get().(*Scope)
+	s.parent = c
+	return s
+}
+
+// Get returns the value for key, asking the parent cache only the first time.
+// Misses are memoized too.
+func (s *Scope) Get(key string) (string, bool) {
+	key = s.parent.normalizeKey(key)
+	if entry, ok := s.local[key]; ok {
+		return entry.value, entry.ok
+	}
+
+	value, ok := s.parent.get(key)
+	s.local[key] = scopeEntry{value: value, ok: ok}
+	return value, ok
+}
+
+// Put writes the key-value pair to the parent cache and the scope.
+func (s *Scope) Put(key string, value string) {
+	key = s.parent.normalizeKey(key)
+	err := s.parent.set(key, value, nil, s.parent.ttl)
+	s.local[key] = scopeEntry{value: value, ok: err == nil}
+}
+
+// Delete removes key from the parent cache and the scope.
+// Returns true if the parent held it.
+func (s *Scope) Delete(key string) bool {
+	key = s.parent.normalizeKey(key)
+	s.local[key] = scopeEntry{}
+	return s.parent.Delete(key)
+}
+
+// Release discards the memoized results and returns the scope to a pool.
+// The scope must not be used afterwards.
+func (s *Scope) Release() {
+	clear(s.local)
+	s.parent = nil
+	scopePool.Put(s)
+}
+
+type scopeKey struct{}
+
+// ScopeMiddleware gives every request a Scope over cache, stored in the
+// request context and released once next returns. Handlers retrieve it with
+// ScopeFromContext.
+func ScopeMiddleware(cache *LRUCache, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := cache.RequestScope()
+		defer scope.Release()
+
+		next.ServeHTTP(w, r.WithContext(ContextWithScope(r.Context(), scope)))
+	})
+}
+
+// FiberScopeMiddleware is ScopeMiddleware for Fiber: it stores a Scope over
+// cache in the user context of every request and releases it once the rest
+// of the chain returns. Handlers retrieve it with
+// ScopeFromContext(c.UserContext()).
+func FiberScopeMiddleware(cache *LRUCache) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scope := cache.RequestScope()
+		defer scope.Release()
+
+		c.SetUserContext(ContextWithScope(c.UserContext(), scope))
+		return c.Next()
+	}
+}
+
+// ContextWithScope returns a copy of ctx carrying scope.
+func ContextWithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFromContext returns the Scope stored in ctx, if any.
+func ScopeFromContext(ctx context.Context) (*Scope, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(*Scope)
+	return scope, ok
+}
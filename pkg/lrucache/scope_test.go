@@ -0,0 +1,166 @@
+package lrucache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// countingLocker counts the acquisitions of the wrapped lock.
+type countingLocker struct {
+	sync.RWMutex
+	acquired atomic.Uint64
+}
+
+func (l *countingLocker) Lock()  { l.acquired.Add(1); l.RWMutex.Lock() }
+func (l *countingLocker) RLock() { l.acquired.Add(1); l.RWMutex.RLock() }
+
+func TestScopeMemoizes(t *testing.T) {
+	c, _ := NewLRUCache(10)
+	lock := &countingLocker{}
+	c.mutex = lock
+	c.Put("a", "1")
+
+	scope := c.RequestScope()
+	defer scope.Release()
+	before := lock.acquired.Load()
+	for range 5 {
+		if value, ok := scope.Get("a"); !ok || value != "1" {
+			t.Fatalf("Get(a) = %q, %v, want 1", value, ok)
+		}
+		if _, ok := scope.Get("missing"); ok {
+			t.Fatal("Get(missing) reported a hit")
+		}
+	}
+	if got := lock.acquired.Load() - before; got != 2 {
+		t.Fatalf("10 scoped reads took the parent lock %d times, want 2", got)
+	}
+
+	scope.Put("b", "2")
+	if value, _ := c.Get("b"); value != "2" {
+		t.Fatal("Scope.Put did not write through to the parent")
+	}
+	scope.Delete("a")
+	if _, ok := scope.Get("a"); ok || c.Has("a") {
+		t.Fatal("Scope.Delete left the key in the scope or the parent")
+	}
+}
+
+func TestScopeMiddleware(t *testing.T) {
+	c, _ := NewLRUCache(10)
+	c.Put("a", "1")
+	handler := ScopeMiddleware(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := ScopeFromContext(r.Context())
+		if !ok {
+			t.Error("no scope in the request context")
+			return
+		}
+		value, _ := scope.Get("a")
+		w.Write([]byte(value))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Body.String() != "1" {
+		t.Fatalf("body = %q, want 1", rec.Body.String())
+	}
+}
+
+func TestFiberScopeMiddleware(t *testing.T) {
+	c, _ := NewLRUCache(10)
+	c.Put("a", "1")
+	app := fiber.New()
+	app.Use(FiberScopeMiddleware(c))
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		scope, ok := ScopeFromContext(ctx.UserContext())
+		if !ok {
+			return fiber.NewError(fiber.StatusInternalServerError, "no scope in the user context")
+		}
+		value, _ := scope.Get("a")
+		return ctx.SendString(value)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "1" {
+		t.Fatalf("body = %q, want 1", body)
+	}
+}
+
+// BenchmarkRequestScope simulates requests reading the same key five times,
+// directly from the cache and through a Scope, and reports the parent lock
+// acquisitions per request.
+func BenchmarkRequestScope(b *testing.B) {
+	const readsPerRequest = 5
+	keys := benchKeys(1000)
+
+	run := func(b *testing.B, request func(c *LRUCache, key string)) {
+		c := filledCache(b, len(keys), keys)
+		lock := &countingLocker{}
+		c.mutex = lock
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			request(c, keys[i%len(keys)])
+		}
+		b.ReportMetric(float64(lock.acquired.Load())/float64(b.N), "locks/req")
+	}
+
+	b.Run("direct", func(b *testing.B) {
+		run(b, func(c *LRUCache, key string) {
+			for range readsPerRequest {
+				c.Get(key)
+			}
+		})
+	})
+	b.Run("scope", func(b *testing.B) {
+		run(b, func(c *LRUCache, key string) {
+			scope := c.RequestScope()
+			for range readsPerRequest {
+				scope.Get(key)
+			}
+			scope.Release()
+		})
+	})
+	b.Run("direct/parallel", func(b *testing.B) {
+		c := filledCache(b, len(keys), keys)
+		b.ReportAllocs()
+		b.ResetTimer()
+		var next atomic.Uint64
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)) * 7919
+			for pb.Next() {
+				for range readsPerRequest {
+					c.Get(keys[i%len(keys)])
+				}
+				i++
+			}
+		})
+	})
+	b.Run("scope/parallel", func(b *testing.B) {
+		c := filledCache(b, len(keys), keys)
+		b.ReportAllocs()
+		b.ResetTimer()
+		var next atomic.Uint64
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)) * 7919
+			for pb.Next() {
+				scope := c.RequestScope()
+				for range readsPerRequest {
+					scope.Get(keys[i%len(keys)])
+				}
+				scope.Release()
+				i++
+			}
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package lrucache
+
+// WithSegments turns the cache into a segmented LRU (SLRU).
+// New entries go into a probationary segment sized at probationaryFraction of the
+// capacity and are only promoted to the protected segment on a second hit.
+// Eviction takes the probationary tail first, and overflow from the protected
+// segment is demoted back to the head of the probationary segment.
+// Fractions outside (0, 1) leave segmentation disabled.
+func WithSegments(probationaryFraction float64) Option {
+	return func(c *LRUCache) {
+		if probationaryFraction <= 0 || probationaryFraction >= 1 {
+			return
+		}
+		c.probationaryFraction = probationaryFraction
+		c.resizeSegments()
+	}
+}
+
+// resizeSegments recomputes the protected segment limit from the capacity.
+func (c *LRUCache) resizeSegments() {
+	if c.probationaryFraction == 0 {
+		return
+	}
+
+	probationary := int(float64(c.Capacity) * c.probationaryFraction)
+	if probationary < 1 {
+		probationary = 1
+	}
+	c.protectedCap = max(c.Capacity-probationary, 0)
+
+	for c.protectedLen > c.protectedCap {
+		c.demote()
+	}
+}
+
+// promote moves a node to the head of the protected segment,
+// demoting the protected tail if the segment overflows.
+func (c *LRUCache) promote(node *Node) {
+	c.removeNode(node)
+	if c.protectedCap == 0 {
+		// Too small for a protected segment, behave like a plain LRU
+		c.addToProbation(node)
+		return
+	}
+
+	c.addToHead(node)
+	node.protected = true
+	c.protectedLen++
+
+	if c.protectedLen > c.protectedCap {
+		c.demote()
+	}
+}
+
+// demote moves the last protected node into the probationary segment.
+func (c *LRUCache) demote() {
+	// The last protected node sits right before the probationary segment
+	demoted := c.Tail
+	if c.probation != nil {
+		demoted = c.probation.Prev
+	}
+	demoted.protected = false
+	c.protectedLen--
+	c.probation = demoted
+}
+
+// addToProbation adds a node to the head of the probationary segment.
+func (c *LRUCache) addToProbation(node *Node) {
+	if c.probation == nil {
+		c.addToTail(node)
+	} else {
+		c.insertBefore(node, c.probation)
+	}
+	c.probation = node
+}
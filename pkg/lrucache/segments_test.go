@@ -0,0 +1,127 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+)
+
+// checkSegments fails unless the protected entries form the front of the list,
+// counted by protectedLen and within protectedCap.
+func checkSegments(t *testing.T, c *LRUCache) {
+	t.Helper()
+	checkIntegrity(t, c)
+
+	protected := 0
+	inProbation := false
+	for node := c.Head; node != nil; node = node.Next {
+		if node == c.probation {
+			inProbation = true
+		}
+		if node.protected == inProbation {
+			t.Fatalf("node %q is on the wrong side of the probationary boundary", node.Key)
+		}
+		if node.protected {
+			protected++
+		}
+	}
+	if protected != c.protectedLen {
+		t.Fatalf("%d protected nodes, protectedLen = %d", protected, c.protectedLen)
+	}
+	if c.protectedLen > c.protectedCap {
+		t.Fatalf("protectedLen %d exceeds protectedCap %d", c.protectedLen, c.protectedCap)
+	}
+}
+
+// scanTrace replays rounds in which a hot working set is requested twice and
+// then a scan of keys that are never requested again passes through. It
+// returns the hits on the first pass over the hot keys, which are only
+// possible if they survived the previous scan.
+func scanTrace(c *LRUCache, hot, scan, rounds int) (hits int) {
+	next := 0
+	for r := 0; r < rounds; r++ {
+		for pass := 0; pass < 2; pass++ {
+			for i := 0; i < hot; i++ {
+				key := "hot" + strconv.Itoa(i)
+				if _, ok := c.Get(key); !ok {
+					c.Put(key, key)
+				} else if pass == 0 {
+					hits++
+				}
+			}
+		}
+		for i := 0; i < scan; i++ {
+			c.Put("scan"+strconv.Itoa(next), "")
+			next++
+		}
+	}
+	return hits
+}
+
+func TestSegmentsResistScans(t *testing.T) {
+	const capacity, hot, scan, rounds = 100, 50, 100, 20
+
+	plain, _ := NewLRUCache(capacity)
+	segmented, _ := NewLRUCacheWithOptions(capacity, WithSegments(0.2))
+
+	plainHits := scanTrace(plain, hot, scan, rounds)
+	segmentedHits := scanTrace(segmented, hot, scan, rounds)
+	t.Logf("hot hits: LRU %d, SLRU %d of %d lookups", plainHits, segmentedHits, hot*rounds)
+
+	if plainHits != 0 {
+		t.Fatalf("plain LRU kept hot keys through a scan larger than the cache: %d hits", plainHits)
+	}
+	if want := hot * (rounds - 1); segmentedHits < want {
+		t.Fatalf("SLRU hot hits = %d, want at least %d", segmentedHits, want)
+	}
+	checkSegments(t, segmented)
+
+	stats := segmented.Stats()
+	if stats.ProtectedSize != hot || stats.ProbationarySize != capacity-hot {
+		t.Fatalf("segment sizes = %d protected, %d probationary, want %d and %d",
+			stats.ProtectedSize, stats.ProbationarySize, hot, capacity-hot)
+	}
+}
+
+func TestSegmentsPromoteOnSecondHit(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithSegments(0.2))
+	c.Put("a", "1")
+	if c.Head.protected {
+		t.Fatal("a new entry started in the protected segment")
+	}
+	c.Get("a")
+	if !c.Cache["a"].protected {
+		t.Fatal("an entry was not promoted on its second hit")
+	}
+	checkSegments(t, c)
+}
+
+func TestSegmentsResizeDemotesProtected(t *testing.T) {
+	c, _ := NewLRUCacheWithOptions(10, WithSegments(0.2))
+	for i := 0; i < 10; i++ {
+		key := strconv.Itoa(i)
+		c.Put(key, key)
+		c.Get(key)
+	}
+	if c.protectedLen != 8 {
+		t.Fatalf("protectedLen = %d, want 8", c.protectedLen)
+	}
+
+	if err := c.Resize(5); err != nil {
+		t.Fatal(err)
+	}
+	checkSegments(t, c)
+	if c.protectedCap != 4 || c.protectedLen != 4 {
+		t.Fatalf("after Resize(5): protectedLen %d, protectedCap %d, want 4 and 4", c.protectedLen, c.protectedCap)
+	}
+	// The most recently promoted entries stay protected
+	for _, key := range []string{"9", "8", "7", "6"} {
+		if node, ok := c.Cache[key]; !ok || !node.protected {
+			t.Fatalf("key %q is not protected after the shrink", key)
+		}
+	}
+
+	if err := c.Resize(20); err != nil {
+		t.Fatal(err)
+	}
+	checkSegments(t, c)
+}
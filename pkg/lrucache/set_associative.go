@@ -0,0 +1,118 @@
+package lrucache
+
+import (
+	"errors"
+	"hash/fnv"
+	"hash/maphash"
+)
+
+// SetAssociativeCache models an N-way set-associative cache, as found in CPUs:
+// keys map to one of sets independent LRU sets, each holding ways entries, so
+// eviction only competes within a set. Total capacity is sets * ways.
+type SetAssociativeCache struct {
+	sets []*LRUCache
+	hash func(key string) uint64
+}
+
+var _ Cache = (*SetAssociativeCache)(nil)
+
+// SetAssociativeOption configures a SetAssociativeCache.
+type SetAssociativeOption func(*SetAssociativeCache)
+
+// WithShardHasher maps keys to sets with fn(key) % sets instead of the
+// default, a maphash hasher seeded per cache. Pass FNVShardHasher for set
+// placement that is the same in every process, e.g. to compare runs; FNV is
+// fixed and public, so only do that when callers cannot choose the keys.
+func WithShardHasher(fn func(key string) uint64) SetAssociativeOption {
+	return func(c *SetAssociativeCache) {
+		if fn != nil {
+			c.hash = fn
+		}
+	}
+}
+
+// SeededShardHasher returns a maphash-based hasher with a random seed, so
+// the set a key maps to cannot be predicted from outside the process. Every
+// SetAssociativeCache uses one unless WithShardHasher says otherwise.
+func SeededShardHasher() func(key string) uint64 {
+	seed := maphash.MakeSeed()
+	return func(key string) uint64 { return maphash.String(seed, key) }
+}
+
+// FNVShardHasher is a set hasher for WithShardHasher: the 32-bit FNV-1a hash
+// of key, the same in every process.
+func FNVShardHasher(key string) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return uint64(h.Sum32())
+}
+
+// NewSetAssociativeCache creates a cache of sets sets holding ways entries each.
+func NewSetAssociativeCache(sets, ways int, opts ...SetAssociativeOption) (*SetAssociativeCache, error) {
+	if sets <= 0 {
+		return nil, errors.New("invalid sets: must be greater than 0")
+	}
+
+	c := &SetAssociativeCache{
+		sets: make([]*LRUCache, sets),
+		hash: SeededShardHasher(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for i := range c.sets {
+		set, err := NewLRUCache(ways)
+		if err != nil {
+			return nil, err
+		}
+		c.sets[i] = set
+	}
+	return c, nil
+}
+
+// set returns the set a key maps to: hash(key) % sets.
+func (c *SetAssociativeCache) set(key string) *LRUCache {
+	return c.sets[c.hash(key)%uint64(len(c.sets))]
+}
+
+// Get retrieves the value for a given key from its set.
+func (c *SetAssociativeCache) Get(key string) (string, bool) {
+	return c.set(key).Get(key)
+}
+
+// Put adds a key-value pair, evicting the least recently used entry of its set if full.
+func (c *SetAssociativeCache) Put(key string, value string) {
+	c.set(key).Put(key, value)
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *SetAssociativeCache) Delete(key string) bool {
+	return c.set(key).Delete(key)
+}
+
+// Has checks if the cache contains a specific key.
+func (c *SetAssociativeCache) Has(key string) bool {
+	return c.set(key).Has(key)
+}
+
+// Clear removes all items from every set.
+func (c *SetAssociativeCache) Clear() {
+	for _, set := range c.sets {
+		set.Clear()
+	}
+}
+
+// Size returns the current number of items across all sets.
+func (c *SetAssociativeCache) Size() int {
+	size := 0
+	for _, set := range c.sets {
+		size += set.Size()
+	}
+	return size
+}
+
+// Capacity returns the total capacity, sets * ways.
+func (c *SetAssociativeCache) Capacity() int {
+	return len(c.sets) * c.sets[0].Capacity
+}
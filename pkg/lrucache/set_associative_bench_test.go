@@ -0,0 +1,59 @@
+package lrucache
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"testing"
+)
+
+// setAssociativeShapes are the sets x ways layouts compared against a flat
+// LRU of the same total capacity.
+var setAssociativeShapes = []struct{ sets, ways int }{
+	{1, 1024},
+	{64, 16},
+	{256, 4},
+	{1024, 1},
+}
+
+// BenchmarkSetAssociative runs a read-through workload with a skewed key
+// distribution against each layout and a flat LRU of equal capacity, and
+// reports the hit rate next to the time per operation.
+func BenchmarkSetAssociative(b *testing.B) {
+	const capacity = 1024
+	keys := benchKeys(8 * capacity)
+	zipf := rand.NewZipf(rand.New(rand.NewPCG(1, 2)), 1.1, 1, uint64(len(keys)-1))
+	trace := make([]string, 1<<16)
+	for i := range trace {
+		trace[i] = keys[zipf.Uint64()]
+	}
+
+	run := func(b *testing.B, c Cache) {
+		b.ReportAllocs()
+		hits := 0
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			key := trace[i%len(trace)]
+			if _, ok := c.Get(key); ok {
+				hits++
+			} else {
+				c.Put(key, key)
+			}
+		}
+		b.ReportMetric(float64(hits)/float64(b.N), "hits/op")
+	}
+
+	b.Run("flat", func(b *testing.B) {
+		c, _ := NewLRUCache(capacity)
+		run(b, c)
+	})
+	for _, shape := range setAssociativeShapes {
+		name := "sets=" + strconv.Itoa(shape.sets) + "/ways=" + strconv.Itoa(shape.ways)
+		b.Run(name, func(b *testing.B) {
+			c, err := NewSetAssociativeCache(shape.sets, shape.ways)
+			if err != nil {
+				b.Fatal(err)
+			}
+			run(b, c)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSetAssociativeDistribution(t *testing.T) {
+	const keys, sets = 1_000_000, 64
+	for name, hasher := range map[string]func(string) uint64{
+		"fnv":    FNVShardHasher,
+		"seeded": SeededShardHasher(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			occupancy := make([]int, sets)
+			for i := range keys {
+				occupancy[hasher("key"+strconv.Itoa(i))%sets]++
+			}
+			want := keys / sets
+			for set, n := range occupancy {
+				// 4% is five standard deviations for a uniform hash
+				if n < want*96/100 || n > want*104/100 {
+					t.Fatalf("set %d holds %d keys, want %d ± 4%%", set, n, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetAssociativeRouting(t *testing.T) {
+	c, _ := NewSetAssociativeCache(8, 2, WithShardHasher(FNVShardHasher))
+	for _, key := range []string{"a", "product_1", "user:42"} {
+		c.Put(key, "v")
+		if !c.sets[FNVShardHasher(key)%8].Has(key) {
+			t.Fatalf("%s is not in set FNVShardHasher(key) %% 8", key)
+		}
+	}
+
+	// The default seeded hasher routes a key to the same set every time.
+	c, _ = NewSetAssociativeCache(8, 2)
+	for _, key := range []string{"a", "product_1", "user:42"} {
+		c.Put(key, "v")
+		if !c.set(key).Has(key) || c.set(key) != c.sets[c.hash(key)%8] {
+			t.Fatalf("%s is not in the set its hash picks", key)
+		}
+	}
+
+	// A custom hasher routes every key to the set it picks.
+	c, _ = NewSetAssociativeCache(4, 2, WithShardHasher(func(string) uint64 { return 3 }))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	if c.sets[3].Size() != 2 || c.Size() != 2 || c.Has("a") {
+		t.Fatalf("set sizes %d/%d, want all keys competing in set 3", c.sets[3].Size(), c.Size())
+	}
+}
@@ -0,0 +1,92 @@
+package lrucache
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// SignedCache is an LRU cache that appends an HMAC-SHA256 signature to every
+// stored value and verifies it on Get, to detect values tampered with where
+// the cache storage is shared. The signature covers the key too, so a value
+// cannot be moved to another key either. Values are not encrypted; see
+// EncryptedCache for that.
+type SignedCache struct {
+	cache  *LRUCache
+	secret []byte
+}
+
+var _ Cache = (*SignedCache)(nil)
+
+// NewSignedCache creates a new SignedCache Instance with the specified
+// capacity, signing values with secret.
+func NewSignedCache(capacity int, secret []byte) (*SignedCache, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("invalid secret: must not be empty")
+	}
+
+	cache, err := NewLRUCache(capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedCache{cache: cache, secret: append([]byte(nil), secret...)}, nil
+}
+
+// Get retrieves the value for a given key after verifying its signature.
+// A tampered entry is deleted and reported as missing, unless a concurrent
+// Put has already replaced it.
+func (c *SignedCache) Get(key string) (string, bool) {
+	stored, ok := c.cache.Get(key)
+	if !ok {
+		return "", false
+	}
+
+	if len(stored) < sha256.Size {
+		c.cache.CompareAndDelete(key, stored)
+		return "", false
+	}
+	value, signature := stored[:len(stored)-sha256.Size], stored[len(stored)-sha256.Size:]
+	if !hmac.Equal([]byte(signature), c.sign(key, value)) {
+		c.cache.CompareAndDelete(key, stored)
+		return "", false
+	}
+	return value, true
+}
+
+// Put signs the value and stores it under key.
+func (c *SignedCache) Put(key string, value string) {
+	c.cache.Put(key, value+string(c.sign(key, value)))
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *SignedCache) Delete(key string) bool {
+	return c.cache.Delete(key)
+}
+
+// Has checks if the cache contains a specific key, without verifying it.
+func (c *SignedCache) Has(key string) bool {
+	return c.cache.Has(key)
+}
+
+// Clear removes all items from the cache.
+func (c *SignedCache) Clear() {
+	c.cache.Clear()
+}
+
+// Size returns the current number of items in the cache.
+func (c *SignedCache) Size() int {
+	return c.cache.Size()
+}
+
+// sign returns the HMAC of the length-prefixed key followed by the value.
+func (c *SignedCache) sign(key, value string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(key)))
+	mac.Write(length[:])
+	mac.Write([]byte(key))
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
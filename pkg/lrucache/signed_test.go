@@ -0,0 +1,31 @@
+package lrucache
+
+import "testing"
+
+func TestSignedCacheDetectsTampering(t *testing.T) {
+	c, err := NewSignedCache(4, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Put("k", "value")
+	if value, ok := c.Get("k"); !ok || value != "value" {
+		t.Fatalf("Get = %q, %v, want value", value, ok)
+	}
+
+	stored, _ := c.cache.Get("k")
+	c.cache.Put("moved", stored)
+	for _, tc := range []struct{ key, stored string }{
+		{"moved", stored},
+		{"k", "VALUE" + stored[len("value"):]},
+		{"k", "short"},
+	} {
+		c.cache.Put(tc.key, tc.stored)
+		if _, ok := c.Get(tc.key); ok || c.Has(tc.key) {
+			t.Fatalf("tampered %s = %q verified or was kept", tc.key, tc.stored)
+		}
+	}
+
+	if _, err := NewSignedCache(4, nil); err == nil {
+		t.Fatal("NewSignedCache accepted an empty secret")
+	}
+}
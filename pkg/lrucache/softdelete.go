@@ -0,0 +1,43 @@
+package lrucache
+
+// SoftDelete marks key as deleted without unlinking it, which is cheaper than
+// Delete under write-heavy load. Lookups treat the entry as missing from then
+// on, but it keeps its slot, still counts toward Size and the capacity, and
+// may be evicted as usual until Compact removes it. Putting the key again
+// revives it with the new value. Returns true if the key was present.
+func (c *LRUCache) SoftDelete(key string) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if !ok || !c.live(node) {
+		return false
+	}
+	node.deleted = true
+	return true
+}
+
+// Compact removes every soft-deleted entry in a single pass under the write
+// lock and returns how many it removed. The eviction callbacks see them as
+// ReasonDeleted.
+func (c *LRUCache) Compact() int {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	removed := 0
+	for node := c.Tail; node != nil; {
+		prev := node.Prev
+		if node.deleted {
+			c.removeEntry(node, ReasonDeleted)
+			removed++
+		}
+		node = prev
+	}
+	return removed
+}
+
+// live reports whether a node is neither soft-deleted nor expired.
+func (c *LRUCache) live(node *Node) bool {
+	return !node.deleted && !c.expired(node)
+}
@@ -0,0 +1,221 @@
+package lrucache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+// SQLStore is a persistent cache level backed by a SQLite database through
+// database/sql, for single-node tools that want cached data to survive
+// restarts, e.g. as the last level of a Chain. The package imports no driver:
+// open db with one registered by the program, such as
+// modernc.org/sqlite or github.com/mattn/go-sqlite3.
+//
+// Entries live in one table, lrucache_entries (key TEXT PRIMARY KEY,
+// value BLOB, expires_at INTEGER), where expires_at is in Unix milliseconds
+// and 0 for entries that never expire. SQLStore does not bound its size.
+// The Cache methods treat database errors as misses or drop the write; use
+// Set and Remove to see them.
+type SQLStore struct {
+	db     *sql.DB
+	get    *sql.Stmt
+	set    *sql.Stmt
+	delete *sql.Stmt
+	now    func() time.Time
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ Cache = (*SQLStore)(nil)
+
+const sqlSchema = `CREATE TABLE IF NOT EXISTS lrucache_entries (
+	key        TEXT PRIMARY KEY,
+	value      BLOB NOT NULL,
+	expires_at INTEGER NOT NULL DEFAULT 0
+)`
+
+const (
+	sqlGet    = `SELECT value FROM lrucache_entries WHERE key = ? AND (expires_at = 0 OR expires_at > ?)`
+	sqlSet    = `INSERT OR REPLACE INTO lrucache_entries (key, value, expires_at) VALUES (?, ?, ?)`
+	sqlDelete = `DELETE FROM lrucache_entries WHERE key = ?`
+	sqlPurge  = `DELETE FROM lrucache_entries WHERE expires_at != 0 AND expires_at <= ?`
+	sqlSize   = `SELECT COUNT(*) FROM lrucache_entries WHERE expires_at = 0 OR expires_at > ?`
+	sqlClear  = `DELETE FROM lrucache_entries`
+)
+
+// NewSQLStore creates the table in db if it is missing and prepares the
+// statements. A positive purgeInterval deletes expired rows in the background
+// at that interval until Close; expired rows are never returned either way.
+func NewSQLStore(db *sql.DB, purgeInterval time.Duration) (*SQLStore, error) {
+	if db == nil {
+		return nil, errors.New("invalid db: must not be nil")
+	}
+	if _, err := db.Exec(sqlSchema); err != nil {
+		return nil, err
+	}
+
+	s := &SQLStore{db: db, now: time.Now}
+	var err error
+	for _, p := range []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&s.get, sqlGet},
+		{&s.set, sqlSet},
+		{&s.delete, sqlDelete},
+	} {
+		if *p.stmt, err = db.Prepare(p.query); err != nil {
+			s.closeStmts()
+			return nil, err
+		}
+	}
+
+	if purgeInterval > 0 {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.purgeEvery(purgeInterval)
+	}
+	return s, nil
+}
+
+// Get retrieves the value for a given key, reporting a miss on database errors.
+func (s *SQLStore) Get(key string) (string, bool) {
+	var value []byte
+	if err := s.get.QueryRow(key, s.millis()).Scan(&value); err != nil {
+		return "", false
+	}
+	return string(value), true
+}
+
+// Put stores a key-value pair that never expires, dropping it on database errors.
+func (s *SQLStore) Put(key string, value string) {
+	_ = s.Set(key, value, 0)
+}
+
+// Set stores a key-value pair expiring after ttl, or never for a ttl of zero
+// or less.
+func (s *SQLStore) Set(key string, value string, ttl time.Duration) error {
+	_, err := s.set.Exec(key, []byte(value), s.expiresAt(ttl))
+	return err
+}
+
+// SetBatch stores entries in a single transaction, which is much faster than
+// one Set per entry when flushing many writes, e.g. from a write-behind queue.
+// Either all entries are stored or none is. They all expire after ttl, as
+// with Set; Meta is not stored.
+func (s *SQLStore) SetBatch(entries []Entry, ttl time.Duration) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	set := tx.Stmt(s.set)
+	expiresAt := s.expiresAt(ttl)
+	for _, entry := range entries {
+		if _, err := set.Exec(entry.Key, []byte(entry.Value), expiresAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Delete removes a key, reporting false on database errors.
+// Returns true if the key was present.
+func (s *SQLStore) Delete(key string) bool {
+	removed, _ := s.Remove(key)
+	return removed
+}
+
+// Remove deletes a key and reports whether it was present.
+func (s *SQLStore) Remove(key string) (bool, error) {
+	res, err := s.delete.Exec(key)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// Has checks if the store contains a live entry for key.
+func (s *SQLStore) Has(key string) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Clear removes all entries.
+func (s *SQLStore) Clear() {
+	_, _ = s.db.Exec(sqlClear)
+}
+
+// Size returns the number of live entries, counting them in the database.
+func (s *SQLStore) Size() int {
+	var n int
+	if err := s.db.QueryRow(sqlSize, s.millis()).Scan(&n); err != nil {
+		return 0
+	}
+	return n
+}
+
+// PurgeExpired deletes the expired rows and returns how many there were.
+func (s *SQLStore) PurgeExpired(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, sqlPurge, s.millis())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Close stops the purge goroutine and releases the prepared statements.
+// It does not close db.
+func (s *SQLStore) Close() error {
+	s.stopOnce.Do(func() {
+		if s.stop != nil {
+			close(s.stop)
+			<-s.done
+		}
+	})
+	return s.closeStmts()
+}
+
+func (s *SQLStore) purgeEvery(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			_, _ = s.PurgeExpired(context.Background())
+		}
+	}
+}
+
+func (s *SQLStore) closeStmts() error {
+	var errs []error
+	for _, stmt := range []*sql.Stmt{s.get, s.set, s.delete} {
+		if stmt != nil {
+			errs = append(errs, stmt.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expiresAt converts a TTL into the expires_at column value.
+func (s *SQLStore) expiresAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return s.now().Add(ttl).UnixMilli()
+}
+
+func (s *SQLStore) millis() int64 {
+	return s.now().UnixMilli()
+}
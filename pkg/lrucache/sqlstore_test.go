@@ -0,0 +1,128 @@
+package lrucache
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// newTestSQLStore returns a store over a fresh in-memory SQLite database.
+func newTestSQLStore(t *testing.T, purgeInterval time.Duration) (*SQLStore, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to :memory: opens its own database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	s, err := NewSQLStore(db, purgeInterval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, db
+}
+
+func TestSQLStore(t *testing.T) {
+	s, db := newTestSQLStore(t, 0)
+	if _, ok := s.Get("missing"); ok {
+		t.Fatal("Get on an empty store reported a hit")
+	}
+
+	s.Put("a", "1")
+	s.Put("b", "binary\x00value")
+	s.Put("a", "2")
+	if value, ok := s.Get("a"); !ok || value != "2" {
+		t.Fatalf("Get(a) = %q, %v, want the updated value", value, ok)
+	}
+	if value, _ := s.Get("b"); value != "binary\x00value" {
+		t.Fatalf("Get(b) = %q, want the NUL byte kept", value)
+	}
+	if s.Size() != 2 || !s.Has("b") {
+		t.Fatalf("Size = %d, want 2", s.Size())
+	}
+	if !s.Delete("a") || s.Delete("a") || s.Has("a") {
+		t.Fatal("Delete did not report presence correctly")
+	}
+	s.Clear()
+	if s.Size() != 0 {
+		t.Fatalf("Size = %d after Clear, want 0", s.Size())
+	}
+
+	// A second store over the same database sees the rows, as after a restart.
+	s.Put("kept", "v")
+	again, err := NewSQLStore(db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer again.Close()
+	if value, ok := again.Get("kept"); !ok || value != "v" {
+		t.Fatalf("Get(kept) through a new store = %q, %v", value, ok)
+	}
+}
+
+func TestSQLStoreExpiry(t *testing.T) {
+	s, _ := newTestSQLStore(t, 0)
+	now := time.Unix(1_700_000_000, 0)
+	s.now = func() time.Time { return now }
+
+	if err := s.Set("short", "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	s.Set("long", "v", time.Hour)
+	s.Put("forever", "v")
+
+	now = now.Add(2 * time.Minute)
+	if s.Has("short") || !s.Has("long") || s.Size() != 2 {
+		t.Fatalf("expired rows are still visible: size %d", s.Size())
+	}
+	n, err := s.PurgeExpired(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("PurgeExpired = %d, %v, want 1 row", n, err)
+	}
+}
+
+func TestSQLStorePurgesInBackground(t *testing.T) {
+	s, db := newTestSQLStore(t, time.Millisecond)
+	s.Set("k", "v", time.Millisecond)
+	waitFor(t, "the expired row to be purged", func() bool {
+		var rows int
+		db.QueryRow(`SELECT COUNT(*) FROM lrucache_entries`).Scan(&rows)
+		return rows == 0
+	})
+}
+
+func TestSQLStoreSetBatch(t *testing.T) {
+	s, _ := newTestSQLStore(t, 0)
+	entries := make([]Entry, 100)
+	for i := range entries {
+		entries[i] = Entry{Key: strconv.Itoa(i), Value: "v" + strconv.Itoa(i)}
+	}
+	if err := s.SetBatch(entries, 0); err != nil {
+		t.Fatal(err)
+	}
+	if s.Size() != 100 {
+		t.Fatalf("Size = %d, want 100", s.Size())
+	}
+	if value, _ := s.Get("42"); value != "v42" {
+		t.Fatalf("Get(42) = %q, want v42", value)
+	}
+}
+
+func TestSQLStoreAsChainTier(t *testing.T) {
+	s, _ := newTestSQLStore(t, 0)
+	front, _ := NewLRUCache(1)
+	chain := NewChain(front, s)
+
+	chain.Put("a", "1")
+	chain.Put("b", "2") // evicts a from the front tier
+	if value, ok := chain.Get("a"); !ok || value != "1" {
+		t.Fatalf("Get(a) = %q, %v, want it served from SQLite", value, ok)
+	}
+}
@@ -0,0 +1,77 @@
+package lrucache
+
+import "sync"
+
+// BoundedStack is a LIFO stack of key-value pairs with a fixed capacity,
+// built on the same doubly linked Node list as LRUCache. Pushing onto a full
+// stack evicts its bottom, the oldest pair. Keys need not be unique.
+type BoundedStack struct {
+	capacity int
+	list     LRUCache // only its list is used: Head is the top, Tail the bottom
+	size     int
+	mutex    sync.Mutex
+}
+
+// NewBoundedStack creates a new BoundedStack Instance with the specified capacity.
+func NewBoundedStack(capacity int) (*BoundedStack, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &BoundedStack{capacity: capacity}, nil
+}
+
+// Push puts a pair on top of the stack, evicting the bottom one if full.
+func (s *BoundedStack) Push(key string, value string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.size == s.capacity {
+		s.remove(s.list.Tail)
+	}
+
+	s.list.addToHead(&Node{Key: key, Value: value})
+	s.size++
+}
+
+// Pop removes and returns the pair on top of the stack.
+// Returns false if the stack is empty.
+func (s *BoundedStack) Pop() (key string, value string, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	node := s.list.Head
+	if node == nil {
+		return "", "", false
+	}
+	s.remove(node)
+	return node.Key, node.Value, true
+}
+
+// Peek returns the pair on top of the stack without removing it.
+// Returns false if the stack is empty.
+func (s *BoundedStack) Peek() (key string, value string, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	top := s.list.Head
+	if top == nil {
+		return "", "", false
+	}
+	return top.Key, top.Value, true
+}
+
+// Size returns the number of pairs on the stack.
+func (s *BoundedStack) Size() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.size
+}
+
+// remove unlinks a node from the list.
+func (s *BoundedStack) remove(node *Node) {
+	s.list.removeNode(node)
+	node.Prev = nil
+	node.Next = nil
+	s.size--
+}
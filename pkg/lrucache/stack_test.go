@@ -0,0 +1,31 @@
+package lrucache
+
+import "testing"
+
+func TestBoundedStack(t *testing.T) {
+	s, _ := NewBoundedStack(3)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		s.Push(key, "v"+key)
+	}
+	if s.Size() != 3 {
+		t.Fatalf("Size = %d, want 3", s.Size())
+	}
+	if key, value, _ := s.Peek(); key != "d" || value != "vd" {
+		t.Fatalf("Peek = %s, %s, want d, vd", key, value)
+	}
+
+	// a was evicted from the bottom
+	for _, want := range []string{"d", "c", "b"} {
+		if key, _, ok := s.Pop(); !ok || key != want {
+			t.Fatalf("Pop = %s, %v, want %s", key, ok, want)
+		}
+	}
+	if _, _, ok := s.Pop(); ok || s.Size() != 0 {
+		t.Fatal("Pop on an empty stack succeeded")
+	}
+
+	s.Push("e", "ve")
+	if key, _, ok := s.Peek(); !ok || key != "e" {
+		t.Fatalf("Peek after refill = %s, %v, want e", key, ok)
+	}
+}
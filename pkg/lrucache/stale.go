@@ -0,0 +1,68 @@
+package lrucache
+
+// WithServeStaleOnError makes GetOrLoad fall back to the expired value of a
+// key when reloading it fails, instead of returning the loader's error.
+// Expired entries are then kept by GetOrLoad until a reload succeeds; a plain
+// Get still treats them as misses and removes them, and they are evicted as
+// usual. The error is only reported to the WithOnLoadError hook, and each
+// fallback counts in Stats.StaleServed. Use GetOrLoadStale to tell stale
+// values apart.
+func WithServeStaleOnError(enabled bool) Option {
+	return func(c *LRUCache) {
+		c.serveStale = enabled
+	}
+}
+
+// WithOnLoadError calls fn with every error returned by the loader, from
+// GetOrLoad and refresh-ahead reloads alike, on the loading goroutine and
+// outside the cache lock.
+func WithOnLoadError(fn func(key string, err error)) Option {
+	return func(c *LRUCache) {
+		c.onLoadError = fn
+	}
+}
+
+// GetOrLoadStale is like GetOrLoad but also reports whether the value is an
+// expired one served because the loader failed, see WithServeStaleOnError.
+func (c *LRUCache) GetOrLoadStale(key string) (value string, stale bool, err error) {
+	key = c.normalizeKey(key)
+	value, ok, expired, hasExpired := c.lookupOrExpired(key)
+	if ok {
+		return value, false, nil
+	}
+	if c.victimCache != nil {
+		if value, ok := c.fromVictim(key); ok {
+			return value, false, nil
+		}
+	}
+	if c.loader == nil {
+		return "", false, ErrNoLoader
+	}
+	if err := c.validateKey(key); err != nil {
+		return "", false, err
+	}
+
+	value, err = c.load(key)
+	if err != nil && hasExpired {
+		c.staleServed.Add(1)
+		return expired, true, nil
+	}
+	return value, false, err
+}
+
+// lookupOrExpired looks up an already normalized key like get. With
+// WithServeStaleOnError, an expired entry is left in place and its value
+// returned as expired, to fall back to if the reload fails.
+func (c *LRUCache) lookupOrExpired(key string) (value string, ok bool, expired string, hasExpired bool) {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	node, ok := c.Cache[key]
+	if ok && c.serveStale && !node.deleted && c.expired(node) {
+		c.recordLookup(key, false)
+		c.tuneMiss(key)
+		return "", false, node.Value, true
+	}
+	hit, ok := c.hit(key, node, ok)
+	return hit.Value, ok, "", false
+}
@@ -0,0 +1,172 @@
+package lrucache
+
+import "time"
+
+// Stats is a point-in-time view of the cache.
+type Stats struct {
+	Size        int
+	Capacity    int
+	MemoryUsage int64 // estimated bytes, see LRUCache.MemoryUsage
+
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // capacity evictions only
+	SlowLoads uint64 // loader calls over the WithSlowLoaderThreshold
+	Rejected  uint64 // puts refused by the WithKeyValidator validator
+
+	StaleServed  uint64 // expired values served by GetOrLoad, see WithServeStaleOnError
+	VictimHits   uint64 // misses served by the WithVictimCache cache
+	AuditDropped uint64 // records dropped because the WithAuditLog queue was full
+
+	// Writes that were never read: entries evicted or expired, and values
+	// overwritten, without a hit since they were last written. UnreadRatio is
+	// their share of all writes so far, a measure of wasted loads or warming.
+	EvictedUnread     uint64
+	OverwrittenUnread uint64
+	UnreadRatio       float64
+
+	// Segment sizes, zero unless the cache was built WithSegments.
+	ProbationarySize int
+	ProtectedSize    int
+
+	// Operation latencies, empty unless the cache was built WithLatencyTracking.
+	GetLatency LatencySummary
+	PutLatency LatencySummary
+
+	// Auto-tuner state, zero unless the cache was built WithAutoTune.
+	AutoTuneEnabled bool
+	GhostHitRate    float64 // ghost hits per lookup over the last period
+	LastAdjustment  time.Time
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (c *LRUCache) Stats() Stats {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	stats := Stats{
+		Size:        len(c.Cache),
+		Capacity:    c.Capacity,
+		MemoryUsage: c.memoryUsage(),
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Evictions:   c.evictions,
+		SlowLoads:   c.slowLoads.Load(),
+		Rejected:    c.rejected,
+		StaleServed: c.staleServed.Load(),
+		VictimHits:  c.victimHits.Load(),
+
+		AuditDropped: c.auditDropped,
+
+		EvictedUnread:     c.evictedUnread,
+		OverwrittenUnread: c.overwrittenUnread,
+	}
+	if c.writes > 0 {
+		stats.UnreadRatio = float64(c.evictedUnread+c.overwrittenUnread) / float64(c.writes)
+	}
+	if c.probationaryFraction > 0 {
+		stats.ProtectedSize = c.protectedLen
+		stats.ProbationarySize = len(c.Cache) - c.protectedLen
+	}
+	if c.latency != nil {
+		stats.GetLatency = c.latency.get.snapshot()
+		stats.PutLatency = c.latency.put.snapshot()
+	}
+	if c.tuner != nil {
+		stats.AutoTuneEnabled = c.tuner.enabled
+		stats.GhostHitRate = c.tuner.ghostHitRate
+		stats.LastAdjustment = c.tuner.lastAdjustment
+	}
+	return stats
+}
+
+// windowSeconds is how far back the windowed counters reach.
+const windowSeconds = 60
+
+// bucket holds the counters of one second.
+type bucket struct {
+	second    int64
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// bucketFor returns the bucket of the current second, recycling a stale one.
+// The caller must hold the write lock.
+func (c *LRUCache) bucketFor(now time.Time) *bucket {
+	second := now.Unix()
+	// Unix seconds are negative before 1970, where Go's % is negative too
+	b := &c.window[((second%windowSeconds)+windowSeconds)%windowSeconds]
+	if b.second != second {
+		*b = bucket{second: second}
+	}
+	return b
+}
+
+// recordLookup counts a hit or a miss. The caller must hold the write lock.
+func (c *LRUCache) recordLookup(key string, hit bool) {
+	now := c.now()
+	if p := c.profiler.Load(); p != nil {
+		p.record(ProfileEvent{Op: "get", Key: key, Hit: hit, Time: now})
+	}
+
+	b := c.bucketFor(now)
+	if hit {
+		c.hits++
+		b.hits++
+		c.recordEvent("get", key, "hit")
+	} else {
+		c.misses++
+		b.misses++
+		c.recordEvent("get", key, "miss")
+	}
+}
+
+// recordEviction counts a capacity eviction. The caller must hold the write lock.
+func (c *LRUCache) recordEviction() {
+	c.evictions++
+	c.bucketFor(c.now()).evictions++
+}
+
+// WithThrashingThresholds sets the miss rate and evictions-per-lookup rate above
+// which IsThrashing reports true. The defaults are 0.9 and 0.5.
+func WithThrashingThresholds(missRate, evictionRate float64) Option {
+	return func(c *LRUCache) {
+		c.thrashMissRate = missRate
+		c.thrashEvictionRate = evictionRate
+	}
+}
+
+// IsThrashing reports whether, over the last window (at most one minute),
+// the cache both missed and evicted more than the configured thresholds,
+// i.e. it keeps replacing entries that are never read again.
+func (c *LRUCache) IsThrashing(window time.Duration) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	now := c.now().Unix()
+	seconds := int64(window / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	if seconds > windowSeconds {
+		seconds = windowSeconds
+	}
+
+	var hits, misses, evictions uint64
+	for _, b := range c.window {
+		if b.second > now-seconds && b.second <= now {
+			hits += b.hits
+			misses += b.misses
+			evictions += b.evictions
+		}
+	}
+
+	lookups := hits + misses
+	if lookups == 0 {
+		return false
+	}
+	missRate := float64(misses) / float64(lookups)
+	evictionRate := float64(evictions) / float64(lookups)
+	return missRate > c.thrashMissRate && evictionRate > c.thrashEvictionRate
+}
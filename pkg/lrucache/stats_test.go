@@ -0,0 +1,59 @@
+package lrucache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIsThrashing(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(4, WithClock(func() time.Time { return now }))
+
+	// Many distinct keys through a tiny cache: every lookup misses and
+	// every put evicts.
+	for i := range 1000 {
+		key := strconv.Itoa(i)
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, "v")
+		}
+		if i%100 == 0 {
+			now = now.Add(time.Second)
+		}
+	}
+	if !c.IsThrashing(10 * time.Second) {
+		t.Fatalf("IsThrashing = false for a scan through a tiny cache, stats %+v", c.Stats())
+	}
+
+	// A hot set that fits: almost every lookup hits and nothing is evicted.
+	now = now.Add(2 * time.Minute)
+	for i := range 1000 {
+		key := strconv.Itoa(i % 4)
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, "v")
+		}
+		if i%100 == 0 {
+			now = now.Add(time.Second)
+		}
+	}
+	if c.IsThrashing(10 * time.Second) {
+		t.Fatalf("IsThrashing = true for a hot set that fits, stats %+v", c.Stats())
+	}
+}
+
+func TestIsThrashingBefore1970(t *testing.T) {
+	now := time.Date(1969, time.December, 31, 23, 59, 0, 0, time.UTC)
+	c, _ := NewLRUCacheWithOptions(1, WithClock(func() time.Time { return now }))
+
+	// Cross zero so both negative and positive seconds are used.
+	for i := range 120 {
+		key := strconv.Itoa(i)
+		c.Get(key)
+		c.Put(key, "v")
+		now = now.Add(time.Second)
+	}
+	now = now.Add(-time.Second)
+	if !c.IsThrashing(time.Minute) {
+		t.Fatal("IsThrashing = false around the epoch")
+	}
+}
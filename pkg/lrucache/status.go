@@ -0,0 +1,33 @@
+package lrucache
+
+import "time"
+
+// CacheStatus is a health summary of the cache, ready to be encoded as JSON by
+// an HTTP handler, e.g. c.JSON(cache.Status()) in Fiber.
+type CacheStatus struct {
+	Size        int     `json:"size"`
+	Capacity    int     `json:"capacity"`
+	Utilization float64 `json:"utilization"` // Size / Capacity, in percent
+	HitRate     float64 `json:"hit_rate"`    // hits per lookup since creation, in percent
+	Evictions   uint64  `json:"evictions"`
+	Thrashing   bool    `json:"thrashing"` // IsThrashing over the last minute
+}
+
+// Status returns the current CacheStatus.
+func (c *LRUCache) Status() CacheStatus {
+	stats := c.Stats()
+
+	status := CacheStatus{
+		Size:      stats.Size,
+		Capacity:  stats.Capacity,
+		Evictions: stats.Evictions,
+		Thrashing: c.IsThrashing(time.Minute),
+	}
+	if stats.Capacity > 0 {
+		status.Utilization = float64(stats.Size) / float64(stats.Capacity) * 100
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		status.HitRate = float64(stats.Hits) / float64(total) * 100
+	}
+	return status
+}
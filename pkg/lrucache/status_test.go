@@ -0,0 +1,45 @@
+package lrucache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStatusJSON(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(4, WithClock(func() time.Time { return now }))
+	c.Put("a", "1")
+	c.Put("b", "2")
+	c.Put("c", "3")
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+	c.Get("missing")
+
+	data, err := json.Marshal(c.Status())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"size":3,"capacity":4,"utilization":75,"hit_rate":75,"evictions":0,"thrashing":false}`
+	if string(data) != want {
+		t.Fatalf("Status JSON = %s\nwant          %s", data, want)
+	}
+
+	// A scan through the cache evicts and misses on every lookup.
+	for i := range 100 {
+		key := string(rune('A' + i))
+		c.Get(key)
+		c.Put(key, "v")
+	}
+	status := c.Status()
+	if !status.Thrashing || status.Evictions != 99 || status.Utilization != 100 {
+		t.Fatalf("Status after a scan = %+v, want thrashing, 99 evictions and 100%% utilization", status)
+	}
+
+	unbounded := NewUnboundedLRUCache()
+	unbounded.Put("a", "1")
+	if got := unbounded.Status(); got.Utilization != 0 || got.HitRate != 0 {
+		t.Fatalf("unbounded Status = %+v, want zero utilization and hit rate", got)
+	}
+}
@@ -0,0 +1,143 @@
+package lrucache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// Stream format: the magic bytes followed by the entries, least recently used
+// first. Each entry is a key length and a value length (uint32, little endian)
+// followed by the key and value bytes, as in the filelock file format.
+var streamMagic = []byte("LRUS")
+
+const (
+	// exportChunk is how many entries ExportStream copies per read lock.
+	exportChunk = 256
+	// maxStreamField bounds a key or value read by ImportStream.
+	maxStreamField = 64 << 20
+)
+
+// ExportStream writes the live entries to w, least recently used first, for
+// ImportStream to load into another cache, e.g. to warm up a new instance from
+// a healthy peer. Only the key order is taken in one pass under the read lock;
+// the values are then copied in small chunks and written with the lock
+// released, so writers are not blocked for the length of the export.
+//
+// This is a warm-up aid, not replication: entries added during the export
+// are missing, removed ones are skipped, updated ones may carry their new
+// value, and TTLs and metadata are not included.
+func (c *LRUCache) ExportStream(w io.Writer) error {
+	start := time.Now()
+	n, err := c.exportStream(w)
+	c.logStream("export", n, time.Since(start), err)
+	return err
+}
+
+// exportStream implements ExportStream and reports how many entries it wrote.
+func (c *LRUCache) exportStream(w io.Writer) (int, error) {
+	c.mutex.RLock()
+	keys := make([]string, 0, len(c.Cache))
+	for node := c.Tail; node != nil; node = node.Prev {
+		if c.live(node) {
+			keys = append(keys, node.Key)
+		}
+	}
+	c.mutex.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(streamMagic); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	chunk := make([]Entry, 0, exportChunk)
+	for len(keys) > 0 {
+		n := min(exportChunk, len(keys))
+
+		chunk = chunk[:0]
+		c.mutex.RLock()
+		for _, key := range keys[:n] {
+			if node, ok := c.Cache[key]; ok && c.live(node) {
+				chunk = append(chunk, Entry{Key: node.Key, Value: node.Value})
+			}
+		}
+		c.mutex.RUnlock()
+		keys = keys[n:]
+
+		for _, entry := range chunk {
+			if err := writeStreamEntry(bw, entry); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, bw.Flush()
+}
+
+// ImportStream reads a stream written by ExportStream and puts its entries in
+// order, so the most recently used ones end up the most recent here too.
+// Each entry is put separately without holding the lock across the import.
+// Entries rejected by the byte limit are skipped.
+func (c *LRUCache) ImportStream(r io.Reader) error {
+	start := time.Now()
+	n, err := c.importStream(r)
+	c.logStream("import", n, time.Since(start), err)
+	return err
+}
+
+// importStream implements ImportStream and reports how many entries it read.
+func (c *LRUCache) importStream(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(streamMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(magic, streamMagic) {
+		return 0, errors.New("invalid stream: bad magic")
+	}
+
+	var header [8]byte
+	for read := 0; ; read++ {
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if err == io.EOF {
+				return read, nil
+			}
+			return read, err
+		}
+
+		keyLen := binary.LittleEndian.Uint32(header[:4])
+		valueLen := binary.LittleEndian.Uint32(header[4:])
+		if keyLen > maxStreamField || valueLen > maxStreamField {
+			return read, errors.New("invalid stream: entry too large")
+		}
+
+		data := make([]byte, keyLen+valueLen)
+		if _, err := io.ReadFull(br, data); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return read, err
+		}
+		_ = c.set(c.normalizeKey(string(data[:keyLen])), string(data[keyLen:]), nil, c.ttl)
+	}
+}
+
+// writeStreamEntry writes one length-prefixed entry.
+func writeStreamEntry(w *bufio.Writer, entry Entry) error {
+	var header [8]byte
+	binary.LittleEndian.PutUint32(header[:4], uint32(len(entry.Key)))
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(entry.Value)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.WriteString(entry.Key); err != nil {
+		return err
+	}
+	_, err := w.WriteString(entry.Value)
+	return err
+}
@@ -0,0 +1,183 @@
+package lrucache
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cachingTransport is an http.RoundTripper that serves repeated requests from an LRUCache.
+type cachingTransport struct {
+	underlying http.RoundTripper
+	cache      *LRUCache
+	keyFunc    func(*http.Request) string
+}
+
+// NewCachingTransport wraps underlying so that successful GET responses are stored
+// in cache under keyFunc(req) and replayed for later requests with the same key.
+//
+// Responses are stored as status line, headers and body. Transfer-Encoding is
+// dropped in favour of a Content-Length, a stored Content-Encoding is only
+// replayed to requests that accept it, and the request headers named by Vary
+// must match the ones the response was stored for. Responses with "Vary: *"
+// or "Cache-Control: no-store" are never cached. A Cache-Control max-age
+// becomes the TTL of the stored response, so "max-age=0" is not cached
+// either; responses without one use the cache's default TTL. A nil underlying uses
+// http.DefaultTransport and a nil keyFunc keys on the request URL.
+func NewCachingTransport(underlying http.RoundTripper, cache *LRUCache, keyFunc func(*http.Request) string) http.RoundTripper {
+	if underlying == nil {
+		underlying = http.DefaultTransport
+	}
+	if keyFunc == nil {
+		keyFunc = func(req *http.Request) string { return req.URL.String() }
+	}
+	return &cachingTransport{underlying: underlying, cache: cache, keyFunc: keyFunc}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || hasToken(req.Header, "Cache-Control", "no-store") {
+		return t.underlying.RoundTrip(req)
+	}
+
+	key := t.keyFunc(req)
+	if stored, ok := t.cache.Get(key); ok {
+		if resp, ok := decodeResponse(stored, req); ok {
+			return resp, nil
+		}
+	}
+
+	resp, err := t.underlying.RoundTrip(req)
+	if err != nil || !cacheable(resp) {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.TransferEncoding = nil
+	resp.ContentLength = int64(len(body))
+
+	if stored, err := encodeResponse(resp, body, req); err == nil {
+		t.cache.storeLoaded(t.cache.normalizeKey(key), stored, responseTTL(resp))
+	}
+	return resp, nil
+}
+
+// cacheable reports whether a response may be stored.
+func cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if hasToken(resp.Header, "Cache-Control", "no-store") || hasToken(resp.Header, "Cache-Control", "private") {
+		return false
+	}
+	return !hasToken(resp.Header, "Vary", "*")
+}
+
+// responseTTL maps the max-age of a response to a TTL for storeLoaded:
+// 0 without one, negative when it is already stale.
+func responseTTL(resp *http.Response) time.Duration {
+	for _, value := range resp.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if !strings.EqualFold(name, "max-age") {
+				continue
+			}
+			seconds, err := strconv.Atoi(strings.Trim(arg, `"`))
+			if err != nil || seconds <= 0 {
+				return -1
+			}
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 0
+}
+
+// encodeResponse serializes the response together with the request header
+// values it varies on.
+func encodeResponse(resp *http.Response, body []byte, req *http.Request) (string, error) {
+	vary := url.Values{}
+	for _, name := range headerTokens(resp.Header, "Vary") {
+		vary.Set(name, req.Header.Get(name))
+	}
+
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	stored.Header = resp.Header.Clone()
+	stored.Header.Del("Transfer-Encoding")
+
+	var buf strings.Builder
+	buf.WriteString(vary.Encode())
+	buf.WriteByte('\n')
+	if err := stored.Write(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// decodeResponse rebuilds a stored response, or reports false if it
+// does not apply to req.
+func decodeResponse(stored string, req *http.Request) (*http.Response, bool) {
+	line, raw, ok := strings.Cut(stored, "\n")
+	if !ok {
+		return nil, false
+	}
+	vary, err := url.ParseQuery(line)
+	if err != nil {
+		return nil, false
+	}
+	for name := range vary {
+		if req.Header.Get(name) != vary.Get(name) {
+			return nil, false
+		}
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(strings.NewReader(raw)), req)
+	if err != nil {
+		return nil, false
+	}
+	if encoding := resp.Header.Get("Content-Encoding"); encoding != "" && !hasToken(req.Header, "Accept-Encoding", encoding) {
+		resp.Body.Close()
+		return nil, false
+	}
+	return resp, true
+}
+
+// headerTokens splits a comma separated header into canonical tokens.
+func headerTokens(h http.Header, name string) []string {
+	var tokens []string
+	for _, value := range h.Values(name) {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				if token != "*" {
+					token = textproto.CanonicalMIMEHeaderKey(token)
+				}
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+// hasToken reports whether a comma separated header contains token.
+func hasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			t, _, _ = strings.Cut(strings.TrimSpace(t), ";")
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package lrucache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestCachingTransportMaxAge(t *testing.T) {
+	calls := 0
+	origin := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		w := httptest.NewRecorder()
+		w.Header().Set("Cache-Control", req.URL.Query().Get("cc"))
+		io.WriteString(w, "body")
+		return w.Result(), nil
+	})
+
+	for _, tc := range []struct {
+		cacheControl string
+		cached       bool
+	}{
+		{"max-age=60", true},
+		{"", true},
+		{"max-age=0", false},
+		{"no-store", false},
+	} {
+		t.Run(tc.cacheControl, func(t *testing.T) {
+			c, _ := NewLRUCache(4)
+			client := &http.Client{Transport: NewCachingTransport(origin, c, nil)}
+			calls = 0
+			for range 2 {
+				resp, err := client.Get("http://origin/?cc=" + tc.cacheControl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if string(body) != "body" {
+					t.Fatalf("body = %q", body)
+				}
+			}
+			if want := map[bool]int{true: 1, false: 2}[tc.cached]; calls != want {
+				t.Fatalf("origin called %d times, want %d", calls, want)
+			}
+		})
+	}
+}
+
+func TestCachingTransportUsesMaxAgeAsTTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c, _ := NewLRUCacheWithOptions(4, WithClock(func() time.Time { return now }))
+	origin := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		w := httptest.NewRecorder()
+		w.Header().Set("Cache-Control", "public, max-age=90")
+		return w.Result(), nil
+	})
+	client := &http.Client{Transport: NewCachingTransport(origin, c, nil)}
+	resp, err := client.Get("http://origin/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	now = now.Add(89 * time.Second)
+	if !c.Has("http://origin/") {
+		t.Fatal("response expired before its max-age")
+	}
+	now = now.Add(time.Second)
+	if c.Has("http://origin/") {
+		t.Fatal("response outlived its max-age")
+	}
+}
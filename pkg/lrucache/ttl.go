@@ -0,0 +1,50 @@
+package lrucache
+
+import "time"
+
+// WithTTL sets a default time to live applied by Put.
+// Expired entries are removed lazily when they are next looked up,
+// so Size may still count them until then.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *LRUCache) {
+		c.ttl = ttl
+	}
+}
+
+// PutWithTTL adds a key-value pair that expires after ttl,
+// overriding the default TTL. A ttl of zero or less never expires.
+func (c *LRUCache) PutWithTTL(key string, value string, ttl time.Duration) error {
+	return c.set(c.normalizeKey(key), value, nil, ttl)
+}
+
+// expiry returns the expiration time for an entry stored now with ttl.
+func (c *LRUCache) expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.now().Add(ttl)
+}
+
+// expired reports whether a node is past its expiration time.
+func (c *LRUCache) expired(node *Node) bool {
+	return !node.expiresAt.IsZero() && !c.now().Before(node.expiresAt)
+}
+
+// ExpireMulti removes the given keys under a single write lock and returns
+// the ones that were present, in the order given. The eviction callbacks see
+// them as ReasonExpired.
+func (c *LRUCache) ExpireMulti(keys []string) []string {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	var removed []string
+	for _, key := range keys {
+		node, ok := c.Cache[c.normalizeKey(key)]
+		if !ok || !c.live(node) {
+			continue
+		}
+		c.removeEntry(node, ReasonExpired)
+		removed = append(removed, key)
+	}
+	return removed
+}
@@ -0,0 +1,66 @@
+package lrucache
+
+// Tx runs cache operations inside a WithLock block, with the write lock
+// already held. It is only valid until the block returns; using it afterwards panics.
+type Tx struct {
+	cache *LRUCache
+	done  bool
+}
+
+// WithLock runs fn as one critical section under the write lock, so other
+// goroutines observe the operations fn performs through tx all at once or not
+// at all. fn must only use tx, not the cache itself, which would deadlock.
+// Eviction callbacks and log records produced inside the block are dispatched
+// in order after the lock is released.
+func (c *LRUCache) WithLock(fn func(tx *Tx)) {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	tx := &Tx{cache: c}
+	defer func() { tx.done = true }()
+	fn(tx)
+}
+
+// c returns the cache, panicking once the block has returned.
+func (tx *Tx) c() *LRUCache {
+	if tx.done {
+		panic("lrucache: Tx used after WithLock returned")
+	}
+	return tx.cache
+}
+
+// Get is like LRUCache.Get.
+func (tx *Tx) Get(key string) (string, bool) {
+	c := tx.c()
+	key = c.normalizeKey(key)
+	node, ok := c.Cache[key]
+	hit, ok := c.hit(key, node, ok)
+	return hit.Value, ok
+}
+
+// Put is like LRUCache.PutE, except that the WithOnFull policy does not apply:
+// the cache evicts to make room, since waiting would release the lock.
+func (tx *Tx) Put(key string, value string) error {
+	c := tx.c()
+	key = c.normalizeKey(key)
+	if err := c.put(key, value, nil, c.ttl); err != nil {
+		c.recordEvent("put", key, "rejected")
+		return err
+	}
+	c.recordEvent("put", key, "stored")
+	return nil
+}
+
+// Delete is like LRUCache.Delete.
+func (tx *Tx) Delete(key string) bool {
+	c := tx.c()
+	key = c.normalizeKey(key)
+	node, ok := c.Cache[key]
+	if !ok {
+		c.recordEvent("delete", key, "absent")
+		return false
+	}
+	c.removeEntry(node, ReasonDeleted)
+	c.recordEvent("delete", key, "deleted")
+	return true
+}
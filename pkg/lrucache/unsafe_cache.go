@@ -0,0 +1,214 @@
+package lrucache
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// UnsafeLRUCache is a research prototype of an LRU cache with lock-free reads.
+//
+// WARNING: this type is experimental. It relies on unsafe.Pointer and hand-rolled
+// atomics, has not been hardened the way LRUCache has, and its recency order is
+// only approximate: a Get does not relink its node immediately but pushes it onto
+// a lock-free promotion stack (atomic.CompareAndSwapPointer), which the next writer
+// drains under the mutex before touching the list. Between two writes, evictions
+// may therefore pick an entry that was read very recently. Prefer LRUCache unless
+// you have measured that read contention on its mutex is your bottleneck.
+type UnsafeLRUCache struct {
+	capacity int
+	head     unsafe.Pointer // *unsafeNode, most recently used
+	tail     unsafe.Pointer // *unsafeNode, least recently used
+	nodes    sync.Map       // string -> *unsafeNode
+	size     int64
+
+	promotions unsafe.Pointer // *unsafeNode, top of the promotion stack
+	mutex      sync.Mutex     // serializes writers and list surgery
+}
+
+type unsafeNode struct {
+	key   string
+	value unsafe.Pointer // *string
+	prev  *unsafeNode    // guarded by the writer mutex
+	next  *unsafeNode    // guarded by the writer mutex
+
+	removed    int32          // set once the node left the cache
+	queued     int32          // set while the node sits on the promotion stack
+	nextQueued unsafe.Pointer // *unsafeNode, link in the promotion stack
+}
+
+var _ Cache = (*UnsafeLRUCache)(nil)
+
+// NewUnsafeLRUCache creates a new UnsafeLRUCache Instance with the specified capacity.
+func NewUnsafeLRUCache(capacity int) (*UnsafeLRUCache, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	return &UnsafeLRUCache{capacity: capacity}, nil
+}
+
+// Get retrieves the value for a given key without taking any lock.
+// The promotion to the head is deferred until the next write.
+func (c *UnsafeLRUCache) Get(key string) (string, bool) {
+	v, ok := c.nodes.Load(key)
+	if !ok {
+		return "", false
+	}
+
+	node := v.(*unsafeNode)
+	if atomic.LoadInt32(&node.removed) == 1 {
+		return "", false
+	}
+	value := *(*string)(atomic.LoadPointer(&node.value))
+
+	// Queue the node for promotion unless it is already waiting
+	if atomic.CompareAndSwapInt32(&node.queued, 0, 1) {
+		for {
+			top := atomic.LoadPointer(&c.promotions)
+			atomic.StorePointer(&node.nextQueued, top)
+			if atomic.CompareAndSwapPointer(&c.promotions, top, unsafe.Pointer(node)) {
+				break
+			}
+		}
+	}
+	return value, true
+}
+
+// Put adds a key-value pair to the cache.
+func (c *UnsafeLRUCache) Put(key string, value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.drainPromotions()
+
+	if v, ok := c.nodes.Load(key); ok {
+		node := v.(*unsafeNode)
+		atomic.StorePointer(&node.value, unsafe.Pointer(&value))
+		c.moveToHead(node)
+		return
+	}
+
+	if atomic.LoadInt64(&c.size) >= int64(c.capacity) {
+		if tail := (*unsafeNode)(atomic.LoadPointer(&c.tail)); tail != nil {
+			c.remove(tail)
+		}
+	}
+
+	node := &unsafeNode{key: key, value: unsafe.Pointer(&value)}
+	c.addToHead(node)
+	c.nodes.Store(key, node)
+	atomic.AddInt64(&c.size, 1)
+}
+
+// Delete removes a key from the cache.
+// Returns true if the key was present.
+func (c *UnsafeLRUCache) Delete(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.drainPromotions()
+
+	v, ok := c.nodes.Load(key)
+	if !ok {
+		return false
+	}
+	c.remove(v.(*unsafeNode))
+	return true
+}
+
+// Has checks if the cache contains a specific key without promoting it.
+func (c *UnsafeLRUCache) Has(key string) bool {
+	v, ok := c.nodes.Load(key)
+	return ok && atomic.LoadInt32(&v.(*unsafeNode).removed) == 0
+}
+
+// Size returns the current number of items in the cache.
+func (c *UnsafeLRUCache) Size() int {
+	return int(atomic.LoadInt64(&c.size))
+}
+
+// Clear removes all items from the cache.
+func (c *UnsafeLRUCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.drainPromotions()
+	for node := (*unsafeNode)(atomic.LoadPointer(&c.head)); node != nil; {
+		next := node.next
+		c.remove(node)
+		node = next
+	}
+}
+
+// drainPromotions applies the promotions queued by lock-free Gets.
+// The caller must hold the writer mutex.
+func (c *UnsafeLRUCache) drainPromotions() {
+	top := atomic.SwapPointer(&c.promotions, nil)
+	if top == nil {
+		return
+	}
+
+	// The stack is newest first; replay it oldest first so the newest read ends at the head
+	var queued []*unsafeNode
+	for node := (*unsafeNode)(top); node != nil; {
+		next := (*unsafeNode)(atomic.LoadPointer(&node.nextQueued))
+		atomic.StorePointer(&node.nextQueued, nil)
+		atomic.StoreInt32(&node.queued, 0)
+		queued = append(queued, node)
+		node = next
+	}
+	for i := len(queued) - 1; i >= 0; i-- {
+		if atomic.LoadInt32(&queued[i].removed) == 0 {
+			c.moveToHead(queued[i])
+		}
+	}
+}
+
+// remove unlinks a node and drops it from the index.
+func (c *UnsafeLRUCache) remove(node *unsafeNode) {
+	atomic.StoreInt32(&node.removed, 1)
+	c.unlink(node)
+	c.nodes.Delete(node.key)
+	atomic.AddInt64(&c.size, -1)
+}
+
+func (c *UnsafeLRUCache) moveToHead(node *unsafeNode) {
+	if (*unsafeNode)(atomic.LoadPointer(&c.head)) == node {
+		return
+	}
+	c.unlink(node)
+	c.addToHead(node)
+}
+
+// unlink removes a node from the doubly linked list.
+func (c *UnsafeLRUCache) unlink(node *unsafeNode) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		atomic.StorePointer(&c.head, unsafe.Pointer(node.next))
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		atomic.StorePointer(&c.tail, unsafe.Pointer(node.prev))
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+// addToHead adds a node to the head of the doubly linked list.
+func (c *UnsafeLRUCache) addToHead(node *unsafeNode) {
+	head := (*unsafeNode)(atomic.LoadPointer(&c.head))
+	node.prev = nil
+	node.next = head
+
+	if head != nil {
+		head.prev = node
+	}
+	atomic.StorePointer(&c.head, unsafe.Pointer(node))
+
+	if atomic.LoadPointer(&c.tail) == nil {
+		atomic.StorePointer(&c.tail, unsafe.Pointer(node))
+	}
+}
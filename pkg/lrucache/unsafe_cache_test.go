@@ -0,0 +1,108 @@
+package lrucache_test
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache"
+	"github.com/CHIRANTAN-001/lrucache/pkg/lrucache/lrucachetest"
+)
+
+func TestUnsafeLRUCacheConformance(t *testing.T) {
+	lrucachetest.RunConformance(t, func(capacity int) lrucache.Cache {
+		c, err := lrucache.NewUnsafeLRUCache(capacity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	})
+}
+
+// unsafeBenchSize is the capacity of the caches the comparison benchmarks use.
+const unsafeBenchSize = 10_000
+
+// runBothCaches runs bench as a sub-benchmark against an LRUCache and an
+// UnsafeLRUCache of unsafeBenchSize, each prefilled with the first
+// unsafeBenchSize of keys.
+func runBothCaches(b *testing.B, keys []string, bench func(b *testing.B, c lrucache.Cache)) {
+	for _, impl := range []struct {
+		name string
+		new  func(int) (lrucache.Cache, error)
+	}{
+		{"LRUCache", func(n int) (lrucache.Cache, error) { return lrucache.NewLRUCache(n) }},
+		{"UnsafeLRUCache", func(n int) (lrucache.Cache, error) { return lrucache.NewUnsafeLRUCache(n) }},
+	} {
+		b.Run(impl.name, func(b *testing.B) {
+			c, err := impl.new(unsafeBenchSize)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, key := range keys[:unsafeBenchSize] {
+				c.Put(key, key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			bench(b, c)
+		})
+	}
+}
+
+// runParallel calls op with a distinct running index from GOMAXPROCS goroutines.
+func runParallel(b *testing.B, op func(i int)) {
+	var next atomic.Uint64
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(next.Add(1)) * 7919
+		for pb.Next() {
+			op(i)
+			i++
+		}
+	})
+}
+
+func unsafeBenchKeys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// BenchmarkUnsafeVsMutexGet compares parallel hits, where UnsafeLRUCache
+// takes no lock and LRUCache takes its write lock to relink.
+func BenchmarkUnsafeVsMutexGet(b *testing.B) {
+	keys := unsafeBenchKeys(unsafeBenchSize)
+	runBothCaches(b, keys, func(b *testing.B, c lrucache.Cache) {
+		runParallel(b, func(i int) { c.Get(keys[i%len(keys)]) })
+	})
+}
+
+// BenchmarkUnsafeVsMutexPut compares parallel Puts that evict on every call.
+func BenchmarkUnsafeVsMutexPut(b *testing.B) {
+	keys := unsafeBenchKeys(2 * unsafeBenchSize)
+	runBothCaches(b, keys, func(b *testing.B, c lrucache.Cache) {
+		runParallel(b, func(i int) {
+			key := keys[i%len(keys)]
+			c.Put(key, key)
+		})
+	})
+}
+
+// BenchmarkUnsafeVsMutexMixed compares 80% Gets, 15% Puts and 5% Deletes
+// over twice as many keys as the caches hold, like BenchmarkMixed.
+func BenchmarkUnsafeVsMutexMixed(b *testing.B) {
+	keys := unsafeBenchKeys(2 * unsafeBenchSize)
+	runBothCaches(b, keys, func(b *testing.B, c lrucache.Cache) {
+		runParallel(b, func(i int) {
+			key := keys[i%len(keys)]
+			switch op := i % 20; {
+			case op < 16:
+				c.Get(key)
+			case op < 19:
+				c.Put(key, key)
+			default:
+				c.Delete(key)
+			}
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package lrucache
+
+import "errors"
+
+// ErrEmptyKey is returned by NonEmptyKeys for the empty key.
+var ErrEmptyKey = errors.New("invalid key: must not be empty")
+
+// WithKeyValidator checks every key before it is stored: Put and its variants,
+// Swap, Upsert, ReplaceAll and GetOrLoad. A key fn rejects is not stored, PutE
+// and friends return fn's error, Put counts it in Stats.Rejected, and GetOrLoad
+// returns the error without calling the loader. Reads are not validated; an
+// invalid key simply misses. fn sees the normalized key and runs under the
+// write lock, so it must be quick and must not call back into the cache.
+func WithKeyValidator(fn func(key string) error) Option {
+	return func(c *LRUCache) {
+		c.validate = fn
+	}
+}
+
+// Key validators for use with WithKeyValidator.
+
+// NonEmptyKeys is a key validator that rejects the empty key with ErrEmptyKey.
+func NonEmptyKeys(key string) error {
+	if key == "" {
+		return ErrEmptyKey
+	}
+	return nil
+}
+
+// MaxKeyLength returns a key validator that rejects keys longer than n bytes
+// with ErrKeyTooLong.
+func MaxKeyLength(n int) func(key string) error {
+	return func(key string) error {
+		if len(key) > n {
+			return ErrKeyTooLong
+		}
+		return nil
+	}
+}
+
+// validateKey runs the key validator, if any.
+func (c *LRUCache) validateKey(key string) error {
+	if c.validate == nil {
+		return nil
+	}
+	return c.validate(key)
+}
@@ -0,0 +1,40 @@
+package lrucache
+
+// GetVersioned is like Get but also returns the version of the value, for
+// optimistic read-modify-write with PutVersioned. Versions come from a
+// cache-wide write counter, so every write of any key gets a new, higher
+// version and a key that was evicted and put again never reuses an old one.
+func (c *LRUCache) GetVersioned(key string) (value string, version uint64, ok bool) {
+	node, ok := c.lookup(c.normalizeKey(key))
+	if !ok {
+		return "", 0, false
+	}
+	return node.Value, node.version, true
+}
+
+// PutVersioned stores value only if the key is still at expectedVersion, as
+// returned by GetVersioned, and reports whether it did. An expectedVersion of
+// zero only inserts a missing key. Any write in between, including a plain
+// Put, makes it fail, so the caller should read again and retry.
+func (c *LRUCache) PutVersioned(key, value string, expectedVersion uint64) bool {
+	key = c.normalizeKey(key)
+	c.mutex.Lock()
+	defer c.unlock()
+
+	var current uint64
+	if node, ok := c.Cache[key]; ok && c.live(node) {
+		current = node.version
+	}
+	if current != expectedVersion {
+		return false
+	}
+
+	if err := c.makeRoom(key, entryCost(key, value, nil)); err != nil {
+		return false
+	}
+	// makeRoom may have waited without the lock
+	if node, ok := c.Cache[key]; ok && c.live(node) && node.version != expectedVersion {
+		return false
+	}
+	return c.put(key, value, nil, c.ttl) == nil
+}
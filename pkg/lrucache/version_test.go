@@ -0,0 +1,96 @@
+package lrucache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPutVersioned(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	if !c.PutVersioned("k", "1", 0) {
+		t.Fatal("PutVersioned with version 0 did not insert a missing key")
+	}
+	if c.PutVersioned("k", "x", 0) {
+		t.Fatal("PutVersioned with version 0 overwrote an existing key")
+	}
+	_, v1, _ := c.GetVersioned("k")
+	if !c.PutVersioned("k", "2", v1) {
+		t.Fatal("PutVersioned at the current version failed")
+	}
+	if c.PutVersioned("k", "stale", v1) {
+		t.Fatal("PutVersioned at a stale version succeeded")
+	}
+	value, v2, _ := c.GetVersioned("k")
+	if value != "2" || v2 <= v1 {
+		t.Fatalf("GetVersioned = %q, %d, want 2 and a version above %d", value, v2, v1)
+	}
+	c.Put("k", "3")
+	if c.PutVersioned("k", "stale", v2) {
+		t.Fatal("PutVersioned succeeded after a plain Put")
+	}
+}
+
+func TestPutVersionedConcurrentIncrements(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("counter", "0")
+
+	const goroutines, increments = 8, 200
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	conflicts := 0
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range increments {
+				for {
+					value, version, _ := c.GetVersioned("counter")
+					n, _ := strconv.Atoi(value)
+					if c.PutVersioned("counter", strconv.Itoa(n+1), version) {
+						break
+					}
+					mutex.Lock()
+					conflicts++
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A lost update would leave the counter short.
+	if value, _ := c.Get("counter"); value != strconv.Itoa(goroutines*increments) {
+		t.Fatalf("counter = %s, want %d", value, goroutines*increments)
+	}
+	t.Logf("%d conflicting puts retried", conflicts)
+}
+
+func TestPutVersionedConflictsRejected(t *testing.T) {
+	c, _ := NewLRUCache(4)
+	c.Put("k", "0")
+
+	const goroutines = 8
+	for round := range 50 {
+		_, version, _ := c.GetVersioned("k")
+		start := make(chan struct{})
+		won := make(chan bool, goroutines)
+		for g := range goroutines {
+			go func() {
+				<-start
+				won <- c.PutVersioned("k", strconv.Itoa(g), version)
+			}()
+		}
+		close(start)
+
+		winners := 0
+		for range goroutines {
+			if <-won {
+				winners++
+			}
+		}
+		if winners != 1 {
+			t.Fatalf("round %d: %d puts at the same version succeeded, want exactly 1", round, winners)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package lrucache
+
+// WithVictimCache spills entries evicted for capacity into victim, typically a
+// larger and cheaper cache, instead of dropping them. A Get or GetOrLoad miss
+// then checks victim and, on a hit, moves the entry back. Spills happen after
+// the lock is released, like the eviction callbacks. Victim hits count in
+// Stats.VictimHits, and as misses of this cache.
+// A cache cannot be its own victim, and cycles through other caches are not
+// supported: they make evictions bounce between the caches. Entries are not
+// spilled under WithSecureErase.
+func WithVictimCache(victim Cache) Option {
+	return func(c *LRUCache) {
+		if self, ok := victim.(*LRUCache); ok && self == c {
+			return
+		}
+		c.victimCache = victim
+	}
+}
+
+// fromVictim moves key back from the victim cache, if it is there.
+// The caller must not hold the lock.
+func (c *LRUCache) fromVictim(key string) (string, bool) {
+	var value string
+	var ok bool
+	if v, atomic := c.victimCache.(interface {
+		GetAndDelete(key string) (string, bool)
+	}); atomic {
+		value, ok = v.GetAndDelete(key)
+	} else if value, ok = c.victimCache.Get(key); ok {
+		c.victimCache.Delete(key)
+	}
+	if !ok {
+		return "", false
+	}
+
+	c.victimHits.Add(1)
+	_ = c.set(key, value, nil, c.ttl)
+	return value, true
+}
@@ -0,0 +1,58 @@
+package lrucache
+
+import "context"
+
+// keyWaiters is a channel closed when its key is stored, with the number of
+// WaitForKey calls blocked on it.
+type keyWaiters struct {
+	stored  chan struct{}
+	waiting int
+}
+
+// WaitForKey blocks until key is present in the cache, then returns its value
+// like Get, e.g. for a pipeline stage waiting on a result another stage
+// caches. It returns ctx.Err() if ctx is done first. Waiting does not hold the
+// cache lock: each waiter sleeps on a channel per key that the next Put of
+// the key closes.
+func (c *LRUCache) WaitForKey(ctx context.Context, key string) (string, error) {
+	key = c.normalizeKey(key)
+	for {
+		c.mutex.Lock()
+		if node, ok := c.Cache[key]; ok && c.live(node) {
+			found, _ := c.hit(key, node, true)
+			c.unlock()
+			return found.Value, nil
+		}
+		if c.waiters == nil {
+			c.waiters = make(map[string]*keyWaiters)
+		}
+		w := c.waiters[key]
+		if w == nil {
+			w = &keyWaiters{stored: make(chan struct{})}
+			c.waiters[key] = w
+		}
+		w.waiting++
+		c.unlock()
+
+		select {
+		case <-w.stored:
+			// The entry may be gone again by the time the lock is retaken
+		case <-ctx.Done():
+			c.mutex.Lock()
+			if w.waiting--; w.waiting == 0 && c.waiters[key] == w {
+				delete(c.waiters, key)
+			}
+			c.unlock()
+			return "", ctx.Err()
+		}
+	}
+}
+
+// signalStored wakes the WaitForKey calls waiting for key.
+// The caller must hold the write lock.
+func (c *LRUCache) signalStored(key string) {
+	if w, ok := c.waiters[key]; ok {
+		close(w.stored)
+		delete(c.waiters, key)
+	}
+}